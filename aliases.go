@@ -3,7 +3,8 @@ package years
 import "time"
 
 const (
-	daysInWeek = 7
+	daysInWeek      = 7
+	monthsInQuarter = 3
 )
 
 // coreAliases holds all registered aliases
@@ -0,0 +1,179 @@
+package years
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Period is a half-open time range [Start, End).
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Contains checks if t is in the period.
+func (p Period) Contains(t time.Time) bool {
+	return !t.Before(p.Start) && t.Before(p.End)
+}
+
+// Bucket is a period of a calendar unit (e.g. a single week) with all waypoints whose time is in it.
+type Bucket struct {
+	Period
+
+	Waypoints []Waypoint
+}
+
+// Count returns the number of waypoints in the bucket.
+func (b Bucket) Count() int { return len(b.Waypoints) }
+
+type bucketConfig struct {
+	weekStartsOn time.Weekday
+	includeEmpty bool
+
+	// from and to limit the grouped period (zero values mean no limit)
+	from, to time.Time
+
+	traverseOpts []TraverseOption
+}
+
+// defaultBucketConfig is Sunday-started weeks (same as in aliases) + Past->Future leaves only.
+func defaultBucketConfig() bucketConfig {
+	return bucketConfig{
+		weekStartsOn: time.Sunday,
+		traverseOpts: []TraverseOption{O_FUTURE(), O_LEAVES_ONLY()},
+	}
+}
+
+// BucketOption defines functional options for the GroupBy function.
+type BucketOption func(*bucketConfig)
+
+// WithEmptyBuckets opts to include buckets for periods having no waypoints.
+func WithEmptyBuckets() BucketOption {
+	return func(c *bucketConfig) { c.includeEmpty = true }
+}
+
+// WithWeekStart opts to start weeks on the given weekday (e.g. time.Monday for ISO-8601 weeks).
+func WithWeekStart(weekStartsOn time.Weekday) BucketOption {
+	return func(c *bucketConfig) { c.weekStartsOn = weekStartsOn }
+}
+
+// WithPeriod opts to group only waypoints in the given period (both bounds are inclusive, a zero bound is open).
+// With WithEmptyBuckets, the whole period is covered by buckets, not just the part having waypoints.
+// An open side is covered up to the outermost waypoint, or up to the other bound when there are no waypoints.
+func WithPeriod(from, to time.Time) BucketOption {
+	return func(c *bucketConfig) { c.from, c.to = from, to }
+}
+
+// WithTraverseOptions opts to traverse waypoints with given options.
+// They are applied on top of the default ones (O_FUTURE and O_LEAVES_ONLY).
+func WithTraverseOptions(opts ...TraverseOption) BucketOption {
+	return func(c *bucketConfig) { c.traverseOpts = append(c.traverseOpts, opts...) }
+}
+
+// calendarUnits are units waypoints can be grouped by.
+//
+//nolint:gochecknoglobals // read-only
+var calendarUnits = []DateUnit{Day, Week, Month, Quarter, Year}
+
+// GroupBy buckets waypoints by the given calendar unit (day, week, month, quarter, year) in the given location
+// (nil means UTC). Buckets are ordered chronologically, waypoints inside a bucket keep the traversal order.
+func (v *Voyager) GroupBy(unit DateUnit, loc *time.Location, opts ...BucketOption) ([]Bucket, error) {
+	if !unit.Defined() {
+		return nil, errors.New("could not group by undefined unit")
+	}
+	if !slices.Contains(calendarUnits, unit) {
+		// unit's String() is not used: combined units have no name
+		return nil, fmt.Errorf("could not group by unit %d: only day, week, month, quarter and year are supported", unit)
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	config := defaultBucketConfig()
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	buckets := make([]Bucket, 0)
+	err := v.Traverse(func(w Waypoint) {
+		t := w.Time().In(loc)
+		if !config.from.IsZero() && t.Before(config.from) {
+			return
+		}
+		if !config.to.IsZero() && t.After(config.to) {
+			return
+		}
+
+		start := unit.truncate(t, config.weekStartsOn)
+		i, found := slices.BinarySearchFunc(buckets, start, func(b Bucket, start time.Time) int {
+			return b.Start.Compare(start)
+		})
+		if !found {
+			buckets = slices.Insert(buckets, i, Bucket{Period: Period{Start: start, End: unit.add(start, 1)}})
+		}
+		buckets[i].Waypoints = append(buckets[i].Waypoints, w)
+	}, config.traverseOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("could not traverse: %w", err)
+	}
+
+	if !config.includeEmpty {
+		return buckets, nil
+	}
+
+	// an open side of the period ends at the outermost bucket, or at the other bound when there are no buckets
+	first, last := config.from.In(loc), config.to.In(loc)
+	switch {
+	case len(buckets) > 0:
+		if config.from.IsZero() {
+			first = buckets[0].Start
+		}
+		if config.to.IsZero() {
+			last = buckets[len(buckets)-1].Start
+		}
+	case config.from.IsZero() && config.to.IsZero():
+		return buckets, nil
+	case config.from.IsZero():
+		first = last
+	case config.to.IsZero():
+		last = first
+	}
+
+	filled := make([]Bucket, 0, len(buckets))
+	next := 0
+	for start := unit.truncate(first, config.weekStartsOn); !start.After(last); start = unit.add(start, 1) {
+		if next < len(buckets) && buckets[next].Start.Equal(start) {
+			filled = append(filled, buckets[next])
+			next++
+			continue
+		}
+		filled = append(filled, Bucket{Period: Period{Start: start, End: unit.add(start, 1)}})
+	}
+
+	return filled, nil
+}
+
+// CountBuckets returns the number of waypoints in every bucket.
+func CountBuckets(buckets []Bucket) []int {
+	counts := make([]int, len(buckets))
+	for i, b := range buckets {
+		counts[i] = b.Count()
+	}
+	return counts
+}
+
+// ReduceBuckets aggregates waypoints of every bucket via the given reducer starting from the initial value,
+// e.g. summing sizes of files to get "bytes per month".
+func ReduceBuckets[T any](buckets []Bucket, initial T, reducer func(acc T, w Waypoint) T) []T {
+	results := make([]T, len(buckets))
+	for i, b := range buckets {
+		acc := initial
+		for _, w := range b.Waypoints {
+			acc = reducer(acc, w)
+		}
+		results[i] = acc
+	}
+	return results
+}
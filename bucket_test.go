@@ -0,0 +1,118 @@
+package years_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/amberpixels/years"
+	"github.com/expectto/be"
+)
+
+func TestVoyager_GroupBy(t *testing.T) {
+	voyagerSetup(t)
+
+	v := years.NewVoyager(years.WaypointGroupFromStrings([]string{
+		"2024-03-01", // Friday
+		"2024-03-04", // Monday
+		"2024-03-05",
+		"2024-03-20",
+		"2024-05-02",
+		"2024-07-15",
+	}, "2006-01-02"))
+
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	t.Run("by month", func(t *testing.T) {
+		buckets, err := v.GroupBy(years.Month, time.UTC)
+		be.Require(t, err).To(be.Succeed())
+		be.Require(t, buckets).To(be.HaveLength(3))
+		be.Expect(t, buckets[0].Period).To(be.Eq(years.Period{Start: date(time.March, 1), End: date(time.April, 1)}))
+		be.Expect(t, years.CountBuckets(buckets)).To(be.Eq([]int{4, 1, 1}))
+	})
+
+	t.Run("by month with empty buckets", func(t *testing.T) {
+		buckets, err := v.GroupBy(years.Month, time.UTC, years.WithEmptyBuckets())
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, years.CountBuckets(buckets)).To(be.Eq([]int{4, 0, 1, 0, 1}))
+		be.Expect(t, buckets[1].Start).To(be.Eq(date(time.April, 1)))
+	})
+
+	t.Run("by ISO week in a period", func(t *testing.T) {
+		buckets, err := v.GroupBy(years.Week, time.UTC,
+			years.WithWeekStart(time.Monday),
+			years.WithPeriod(date(time.February, 26), date(time.March, 17)),
+			years.WithEmptyBuckets(),
+		)
+		be.Require(t, err).To(be.Succeed())
+		be.Require(t, buckets).To(be.HaveLength(3))
+		be.Expect(t, buckets[0].Start).To(be.Eq(date(time.February, 26)))
+		be.Expect(t, buckets[2].End).To(be.Eq(date(time.March, 18)))
+		be.Expect(t, years.CountBuckets(buckets)).To(be.Eq([]int{1, 2, 0}))
+	})
+
+	t.Run("in a one-sided period with empty buckets", func(t *testing.T) {
+		buckets, err := v.GroupBy(years.Month, time.UTC,
+			years.WithPeriod(date(time.April, 10), time.Time{}),
+			years.WithEmptyBuckets(),
+		)
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, buckets[0].Start).To(be.Eq(date(time.April, 1)))
+		be.Expect(t, years.CountBuckets(buckets)).To(be.Eq([]int{0, 1, 0, 1}))
+
+		buckets, err = v.GroupBy(years.Month, time.UTC,
+			years.WithPeriod(time.Time{}, date(time.April, 10)),
+			years.WithEmptyBuckets(),
+		)
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, years.CountBuckets(buckets)).To(be.Eq([]int{4, 0}))
+
+		// no waypoints in the period: the set bound's bucket is still there
+		buckets, err = v.GroupBy(years.Month, time.UTC,
+			years.WithPeriod(date(time.August, 10), time.Time{}),
+			years.WithEmptyBuckets(),
+		)
+		be.Require(t, err).To(be.Succeed())
+		be.Require(t, buckets).To(be.HaveLength(1))
+		be.Expect(t, buckets[0].Period).To(be.Eq(years.Period{Start: date(time.August, 1), End: date(time.September, 1)}))
+
+		buckets, err = years.NewVoyager(years.NewWaypointGroup("empty")).GroupBy(years.Month, time.UTC,
+			years.WithPeriod(time.Time{}, date(time.February, 10)),
+			years.WithEmptyBuckets(),
+		)
+		be.Require(t, err).To(be.Succeed())
+		be.Require(t, buckets).To(be.HaveLength(1))
+		be.Expect(t, buckets[0].Start).To(be.Eq(date(time.February, 1)))
+	})
+
+	t.Run("by quarter with reducer", func(t *testing.T) {
+		buckets, err := v.GroupBy(years.Quarter, time.UTC)
+		be.Require(t, err).To(be.Succeed())
+
+		days := years.ReduceBuckets(buckets, "", func(acc string, w years.Waypoint) string {
+			return acc + w.Time().Format("02")
+		})
+		be.Expect(t, days).To(be.Eq([]string{"01040520", "02", "15"}))
+	})
+
+	t.Run("in another location", func(t *testing.T) {
+		loc := time.FixedZone("UTC-1", -3600)
+		buckets, err := v.GroupBy(years.Day, loc)
+		be.Require(t, err).To(be.Succeed())
+		be.Require(t, buckets).To(be.HaveLength(6))
+		// 2024-03-01 00:00 UTC is still February 29th in UTC-1
+		be.Expect(t, buckets[0].Period).To(be.Eq(years.Period{
+			Start: time.Date(2024, time.February, 29, 0, 0, 0, 0, loc),
+			End:   time.Date(2024, time.March, 1, 0, 0, 0, 0, loc),
+		}))
+		be.Expect(t, buckets[0].Waypoints[0].Time()).To(be.Eq(date(time.March, 1)))
+	})
+
+	t.Run("unsupported units", func(t *testing.T) {
+		for _, unit := range []years.DateUnit{years.UnitUndefined, years.UnixSecond, years.Day | years.Month} {
+			_, err := v.GroupBy(unit, time.UTC)
+			be.Expect(t, err).To(be.HaveOccurred())
+		}
+	})
+}
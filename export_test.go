@@ -3,3 +3,10 @@
 package years
 
 var CoreAliases = coreAliases
+
+// IsChildrenLoaded tells if children of a lazy file waypoint were read already.
+func IsChildrenLoaded(w *WaypointFile) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.loaded
+}
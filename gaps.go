@@ -0,0 +1,96 @@
+package years
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// unitWaypoint is a Waypoint that knows the date unit it represents (e.g. a daily file).
+type unitWaypoint interface {
+	Unit() DateUnit
+}
+
+// GapReport describes how waypoints cover a period when one waypoint per unit is expected
+// (e.g. one file per day in a daily-notes tree).
+type GapReport struct {
+	// Unit is the expected unit of waypoints
+	Unit DateUnit
+
+	// Period is the checked period: the requested one or the extent of the tree
+	Period Period
+
+	// Missing are periods having no waypoint
+	Missing []Period
+
+	// Duplicates are buckets having more than one waypoint
+	Duplicates []Bucket
+}
+
+// HasGaps tells if any expected period has no waypoint.
+func (r *GapReport) HasGaps() bool { return len(r.Missing) > 0 }
+
+// Gaps reports which periods of the given unit have no waypoint (and which have duplicates).
+// The checked period is specified via WithPeriod, otherwise the extent of the tree is used.
+// If unit is UnitUndefined, it's detected from waypoints (e.g. TimeNamedWaypointFile's layout):
+// the finest calendar unit among them is used.
+func (v *Voyager) Gaps(unit DateUnit, loc *time.Location, opts ...BucketOption) (*GapReport, error) {
+	config := defaultBucketConfig()
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	if !unit.Defined() {
+		var err error
+		if unit, err = v.detectUnit(config.traverseOpts); err != nil {
+			return nil, err
+		}
+	}
+
+	buckets, err := v.GroupBy(unit, loc, append(slices.Clip(opts), WithEmptyBuckets())...)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &GapReport{Unit: unit, Missing: make([]Period, 0), Duplicates: make([]Bucket, 0)}
+	if len(buckets) > 0 {
+		report.Period = Period{Start: buckets[0].Start, End: buckets[len(buckets)-1].End}
+	}
+	for _, b := range buckets {
+		switch {
+		case b.Count() == 0:
+			report.Missing = append(report.Missing, b.Period)
+		case b.Count() > 1:
+			report.Duplicates = append(report.Duplicates, b)
+		}
+	}
+
+	return report, nil
+}
+
+// detectUnit returns the finest calendar unit among traversed waypoints.
+func (v *Voyager) detectUnit(opts []TraverseOption) (DateUnit, error) {
+	detected := UnitUndefined
+	err := v.Traverse(func(w Waypoint) {
+		uw, ok := w.(unitWaypoint)
+		if !ok {
+			return
+		}
+
+		for _, unit := range calendarUnits {
+			if uw.Unit() == unit && (!detected.Defined() || unit < detected) {
+				detected = unit
+			}
+		}
+	}, opts...)
+	if err != nil {
+		return UnitUndefined, fmt.Errorf("could not traverse: %w", err)
+	}
+
+	if !detected.Defined() {
+		return UnitUndefined, errors.New("could not detect unit of waypoints")
+	}
+
+	return detected, nil
+}
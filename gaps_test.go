@@ -0,0 +1,76 @@
+package years_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/amberpixels/years"
+	"github.com/expectto/be"
+)
+
+func TestVoyager_Gaps(t *testing.T) {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	day := func(month time.Month, d int) years.Period {
+		return years.Period{Start: date(month, d), End: date(month, d+1)}
+	}
+
+	t.Run("daily calendar files", func(t *testing.T) {
+		voyagerSetup(t, "2006", "Jan", "2006-01-02")
+		wf, err := years.NewTimeNamedWaypointFile(filepath.Join(TestDataPath, "calendar1"), "2006/Jan/2006-01-02.txt")
+		be.Require(t, err).To(be.Succeed())
+
+		// unit is detected from the layout
+		report, err := years.NewVoyager(wf).Gaps(years.UnitUndefined, time.UTC,
+			years.WithPeriod(date(time.March, 3), date(time.March, 7)),
+		)
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, report.Unit).To(be.Eq(years.Day))
+		be.Expect(t, report.Period).To(be.Eq(years.Period{Start: date(time.March, 3), End: date(time.March, 8)}))
+		be.Expect(t, report.HasGaps()).To(be.True())
+		be.Expect(t, report.Missing).To(be.Eq([]years.Period{
+			day(time.March, 3),
+			day(time.March, 4),
+			day(time.March, 7),
+		}))
+		be.Expect(t, report.Duplicates).To(be.Empty())
+	})
+
+	t.Run("own extent with duplicates", func(t *testing.T) {
+		voyagerSetup(t, "2006-01-02 15:04")
+		v := years.NewVoyager(years.WaypointGroupFromStrings([]string{
+			"2024-03-01 10:00",
+			"2024-03-01 11:00",
+			"2024-03-03 10:00",
+		}))
+
+		report, err := v.Gaps(years.Day, time.UTC)
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, report.Period).To(be.Eq(years.Period{Start: date(time.March, 1), End: date(time.March, 4)}))
+		be.Expect(t, report.Missing).To(be.Eq([]years.Period{day(time.March, 2)}))
+		be.Require(t, report.Duplicates).To(be.HaveLength(1))
+		be.Expect(t, report.Duplicates[0].Period).To(be.Eq(day(time.March, 1)))
+		be.Expect(t, report.Duplicates[0].Count()).To(be.Eq(2))
+	})
+
+	t.Run("caller's options are kept", func(t *testing.T) {
+		voyagerSetup(t)
+		v := years.NewVoyager(years.WaypointGroupFromStrings([]string{"2024-03-01", "2024-03-03"}))
+
+		opts := make([]years.BucketOption, 1, 2)
+		opts[0] = years.WithWeekStart(time.Monday)
+		_, err := v.Gaps(years.Day, time.UTC, opts...)
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, opts[:2][1] == nil).To(be.True())
+	})
+
+	t.Run("undetectable unit", func(t *testing.T) {
+		voyagerSetup(t)
+		v := years.NewVoyager(years.WaypointGroupFromStrings([]string{"2024-03-01"}))
+
+		_, err := v.Gaps(years.UnitUndefined, time.UTC)
+		be.Expect(t, err).To(be.HaveOccurred())
+	})
+}
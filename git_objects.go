@@ -0,0 +1,522 @@
+package years
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// This file holds a minimal read-only git repository reader (just enough for reading commits):
+// refs (loose and packed), loose objects and packfiles (idx v2, including deltified objects).
+
+const (
+	gitHashSize = 20
+
+	gitObjectCommit   = 1
+	gitObjectTree     = 2
+	gitObjectBlob     = 3
+	gitObjectTag      = 4
+	gitObjectOfsDelta = 6
+	gitObjectRefDelta = 7
+
+	// gitMaxDeltaDepth protects from malformed packs having cyclic deltas
+	gitMaxDeltaDepth = 1000
+)
+
+// gitObjectTypes maps names of object types (as in loose objects headers) to pack object types.
+//
+//nolint:gochecknoglobals // it's ok
+var gitObjectTypes = map[string]int{
+	"commit": gitObjectCommit,
+	"tree":   gitObjectTree,
+	"blob":   gitObjectBlob,
+	"tag":    gitObjectTag,
+}
+
+// gitRepo is a read-only git repository.
+type gitRepo struct {
+	// dir is the git directory (".git" one, or the repository itself if it's bare)
+	dir string
+	// commonDir is the directory of objects and refs: the main repository's git directory
+	// for linked worktrees (see "commondir" file), the git directory itself otherwise
+	commonDir string
+	packs     []*gitPack
+}
+
+// openGitRepo opens the repository at the given path: a working tree (having ".git" dir or file) or a bare one.
+func openGitRepo(path string) (*gitRepo, error) {
+	dir, err := findGitDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	commonDir, err := findGitCommonDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := &gitRepo{dir: dir, commonDir: commonDir}
+	idxPaths, err := filepath.Glob(filepath.Join(commonDir, "objects", "pack", "*.idx"))
+	if err != nil {
+		return nil, fmt.Errorf("could not list packs: %w", err)
+	}
+	for _, idxPath := range idxPaths {
+		pack, err := openGitPack(idxPath)
+		if err != nil {
+			repo.close()
+			return nil, err
+		}
+		repo.packs = append(repo.packs, pack)
+	}
+
+	return repo, nil
+}
+
+// findGitDir returns the git directory of the repository at the given path.
+func findGitDir(path string) (string, error) {
+	dotGit := filepath.Join(path, ".git")
+	stat, err := os.Stat(dotGit)
+	switch {
+	case err == nil && stat.IsDir():
+		return dotGit, nil
+	case err == nil:
+		// ".git" file of linked worktrees and submodules: "gitdir: <path>"
+		content, err := os.ReadFile(dotGit)
+		if err != nil {
+			return "", fmt.Errorf("could not read .git file: %w", err)
+		}
+		dir, ok := strings.CutPrefix(strings.TrimSpace(string(content)), "gitdir: ")
+		if !ok {
+			return "", fmt.Errorf("invalid .git file: %s", dotGit)
+		}
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(path, dir)
+		}
+		return dir, nil
+	}
+
+	// bare repository
+	if _, err := os.Stat(filepath.Join(path, "objects")); err != nil {
+		return "", fmt.Errorf("not a git repository: %s", path)
+	}
+	if _, err := os.Stat(filepath.Join(path, "HEAD")); err != nil {
+		return "", fmt.Errorf("not a git repository: %s", path)
+	}
+
+	return path, nil
+}
+
+// findGitCommonDir returns the directory of objects and refs for the given git directory.
+// Git directories of linked worktrees have a "commondir" file pointing to the main one.
+func findGitCommonDir(dir string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(dir, "commondir"))
+	if errors.Is(err, os.ErrNotExist) {
+		return dir, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("could not read commondir: %w", err)
+	}
+
+	commonDir := strings.TrimSpace(string(content))
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(dir, commonDir)
+	}
+	return commonDir, nil
+}
+
+func (r *gitRepo) close() {
+	for _, pack := range r.packs {
+		_ = pack.file.Close()
+	}
+}
+
+// head returns the name of the branch HEAD points to ("" if HEAD is detached).
+func (r *gitRepo) head() (string, error) {
+	content, err := os.ReadFile(filepath.Join(r.dir, "HEAD"))
+	if err != nil {
+		return "", fmt.Errorf("could not read HEAD: %w", err)
+	}
+
+	ref, ok := strings.CutPrefix(strings.TrimSpace(string(content)), "ref: refs/heads/")
+	if !ok {
+		return "", nil
+	}
+	return ref, nil
+}
+
+// branches returns hashes of local branches by their names. Loose refs take precedence over packed ones.
+func (r *gitRepo) branches() (map[string]string, error) {
+	const headsPrefix = "refs/heads/"
+	branches := make(map[string]string)
+
+	packed, err := os.ReadFile(filepath.Join(r.commonDir, "packed-refs"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("could not read packed refs: %w", err)
+	}
+	for _, line := range strings.Split(string(packed), "\n") {
+		// comments and peeled tags ("^<hash>") are skipped
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "^") {
+			continue
+		}
+		hash, ref, found := strings.Cut(line, " ")
+		if name, ok := strings.CutPrefix(ref, headsPrefix); found && ok {
+			branches[name] = hash
+		}
+	}
+
+	headsDir := filepath.Join(r.commonDir, "refs", "heads")
+	err = filepath.WalkDir(headsDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(headsDir, path)
+		if err != nil {
+			return err
+		}
+		branches[filepath.ToSlash(name)] = strings.TrimSpace(string(content))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not read refs: %w", err)
+	}
+
+	return branches, nil
+}
+
+// readObject returns type and content of the object of the given hash.
+func (r *gitRepo) readObject(hash string) (int, []byte, error) {
+	if len(hash) != 2*gitHashSize {
+		return 0, nil, fmt.Errorf("invalid object hash: %q", hash)
+	}
+
+	objectType, data, err := r.readLooseObject(hash)
+	if err == nil {
+		return objectType, data, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return 0, nil, err
+	}
+
+	rawHash, err := hex.DecodeString(hash)
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid object hash: %q", hash)
+	}
+	for _, pack := range r.packs {
+		if offset, ok := pack.find(rawHash); ok {
+			return pack.readObject(r, offset, 0)
+		}
+	}
+
+	return 0, nil, fmt.Errorf("object %s not found", hash)
+}
+
+// readLooseObject reads a zlib-compressed "<type> <size>\0<content>" object file.
+func (r *gitRepo) readLooseObject(hash string) (int, []byte, error) {
+	f, err := os.Open(filepath.Join(r.commonDir, "objects", hash[:2], hash[2:]))
+	if err != nil {
+		return 0, nil, err
+	}
+	defer f.Close()
+
+	zr, err := zlib.NewReader(f)
+	if err != nil {
+		return 0, nil, fmt.Errorf("could not decompress object %s: %w", hash, err)
+	}
+	defer zr.Close()
+
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		return 0, nil, fmt.Errorf("could not decompress object %s: %w", hash, err)
+	}
+
+	header, data, found := bytes.Cut(raw, []byte{0})
+	typeName, _, _ := strings.Cut(string(header), " ")
+	objectType, known := gitObjectTypes[typeName]
+	if !found || !known {
+		return 0, nil, fmt.Errorf("invalid object %s", hash)
+	}
+
+	return objectType, data, nil
+}
+
+// gitPack is a packfile along with its index (v2).
+type gitPack struct {
+	file *os.File
+
+	// hashes are sorted raw hashes of objects, offsets are their offsets in the pack
+	hashes  []byte
+	offsets []int64
+}
+
+// openGitPack reads the index and opens the pack of the given index path.
+func openGitPack(idxPath string) (*gitPack, error) {
+	idx, err := os.ReadFile(idxPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read pack index: %w", err)
+	}
+
+	const headerSize, fanoutSize = 8, 256 * 4
+	if len(idx) < headerSize+fanoutSize || !bytes.Equal(idx[:4], []byte{0xff, 't', 'O', 'c'}) ||
+		binary.BigEndian.Uint32(idx[4:8]) != 2 {
+		return nil, fmt.Errorf("unsupported pack index (only v2 is supported): %s", idxPath)
+	}
+
+	count := int(binary.BigEndian.Uint32(idx[headerSize+fanoutSize-4 : headerSize+fanoutSize]))
+	hashesStart := headerSize + fanoutSize
+	crcStart := hashesStart + count*gitHashSize
+	offsetsStart := crcStart + count*4
+	largeOffsetsStart := offsetsStart + count*4
+	if len(idx) < largeOffsetsStart {
+		return nil, fmt.Errorf("truncated pack index: %s", idxPath)
+	}
+
+	pack := &gitPack{hashes: idx[hashesStart:crcStart], offsets: make([]int64, count)}
+	for i := range count {
+		offset := binary.BigEndian.Uint32(idx[offsetsStart+i*4:])
+		if offset&0x80000000 == 0 {
+			pack.offsets[i] = int64(offset)
+			continue
+		}
+
+		// the offset is an index in the table of 8-byte offsets (for packs larger than 2GB)
+		large := largeOffsetsStart + int(offset&0x7fffffff)*8
+		if len(idx) < large+8 {
+			return nil, fmt.Errorf("truncated pack index: %s", idxPath)
+		}
+		pack.offsets[i] = int64(binary.BigEndian.Uint64(idx[large:])) //nolint:gosec // offsets fit int64
+	}
+
+	pack.file, err = os.Open(strings.TrimSuffix(idxPath, ".idx") + ".pack")
+	if err != nil {
+		return nil, fmt.Errorf("could not open pack: %w", err)
+	}
+
+	return pack, nil
+}
+
+// find returns the offset of the object of the given raw hash.
+func (p *gitPack) find(rawHash []byte) (int64, bool) {
+	count := len(p.offsets)
+	i := sort.Search(count, func(i int) bool {
+		return bytes.Compare(p.hashes[i*gitHashSize:(i+1)*gitHashSize], rawHash) >= 0
+	})
+	if i < count && bytes.Equal(p.hashes[i*gitHashSize:(i+1)*gitHashSize], rawHash) {
+		return p.offsets[i], true
+	}
+	return 0, false
+}
+
+// readObject reads the object at the given offset, resolving deltas.
+func (p *gitPack) readObject(repo *gitRepo, offset int64, depth int) (int, []byte, error) {
+	if depth > gitMaxDeltaDepth {
+		return 0, nil, errors.New("too deep delta chain")
+	}
+
+	reader := bufio.NewReader(io.NewSectionReader(p.file, offset, 1<<62))
+
+	// header: type (3 bits) and size (variable length, little-endian groups of 7 bits)
+	b, err := reader.ReadByte()
+	if err != nil {
+		return 0, nil, fmt.Errorf("could not read pack object: %w", err)
+	}
+	objectType := int(b>>4) & 0x07
+	for b&0x80 != 0 {
+		if b, err = reader.ReadByte(); err != nil {
+			return 0, nil, fmt.Errorf("could not read pack object: %w", err)
+		}
+	}
+
+	var baseType int
+	var base []byte
+	switch objectType {
+	case gitObjectCommit, gitObjectTree, gitObjectBlob, gitObjectTag:
+	case gitObjectOfsDelta:
+		// negative offset of the base: big-endian groups of 7 bits, adding 1 for every next group
+		b, err := reader.ReadByte()
+		if err != nil {
+			return 0, nil, fmt.Errorf("could not read pack object: %w", err)
+		}
+		relative := int64(b & 0x7f)
+		for b&0x80 != 0 {
+			if b, err = reader.ReadByte(); err != nil {
+				return 0, nil, fmt.Errorf("could not read pack object: %w", err)
+			}
+			relative = ((relative + 1) << 7) | int64(b&0x7f)
+		}
+		if baseType, base, err = p.readObject(repo, offset-relative, depth+1); err != nil {
+			return 0, nil, err
+		}
+	case gitObjectRefDelta:
+		rawHash := make([]byte, gitHashSize)
+		if _, err := io.ReadFull(reader, rawHash); err != nil {
+			return 0, nil, fmt.Errorf("could not read pack object: %w", err)
+		}
+		if baseType, base, err = repo.readObject(hex.EncodeToString(rawHash)); err != nil {
+			return 0, nil, err
+		}
+	default:
+		return 0, nil, fmt.Errorf("unknown pack object type: %d", objectType)
+	}
+
+	zr, err := zlib.NewReader(reader)
+	if err != nil {
+		return 0, nil, fmt.Errorf("could not decompress pack object: %w", err)
+	}
+	defer zr.Close()
+
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return 0, nil, fmt.Errorf("could not decompress pack object: %w", err)
+	}
+
+	if base == nil {
+		return objectType, data, nil
+	}
+
+	patched, err := applyGitDelta(base, data)
+	if err != nil {
+		return 0, nil, err
+	}
+	return baseType, patched, nil
+}
+
+// applyGitDelta applies the delta to the base:
+// the delta is sizes of the base and the result followed by copy (from the base) and insert instructions.
+func applyGitDelta(base, delta []byte) ([]byte, error) {
+	errInvalid := errors.New("invalid delta")
+
+	readSize := func() (int, error) {
+		var size, shift int
+		for {
+			if len(delta) == 0 {
+				return 0, errInvalid
+			}
+			b := delta[0]
+			delta = delta[1:]
+			size |= int(b&0x7f) << shift
+			shift += 7
+			if b&0x80 == 0 {
+				return size, nil
+			}
+		}
+	}
+
+	baseSize, err := readSize()
+	if err != nil || baseSize != len(base) {
+		return nil, errInvalid
+	}
+	resultSize, err := readSize()
+	if err != nil {
+		return nil, errInvalid
+	}
+
+	result := make([]byte, 0, resultSize)
+	for len(delta) > 0 {
+		op := delta[0]
+		delta = delta[1:]
+
+		if op&0x80 == 0 {
+			// insert: op is the number of the following bytes to be inserted
+			n := int(op)
+			if n == 0 || len(delta) < n {
+				return nil, errInvalid
+			}
+			result = append(result, delta[:n]...)
+			delta = delta[n:]
+			continue
+		}
+
+		// copy: bits 0-3 tell which offset bytes are present, bits 4-6 tell which size bytes are
+		var copyOffset, copySize int
+		for i := range 7 {
+			if op&(1<<i) == 0 {
+				continue
+			}
+			if len(delta) == 0 {
+				return nil, errInvalid
+			}
+			if i < 4 {
+				copyOffset |= int(delta[0]) << (8 * i)
+			} else {
+				copySize |= int(delta[0]) << (8 * (i - 4))
+			}
+			delta = delta[1:]
+		}
+		if copySize == 0 {
+			copySize = 0x10000
+		}
+		if copyOffset+copySize > len(base) {
+			return nil, errInvalid
+		}
+		result = append(result, base[copyOffset:copyOffset+copySize]...)
+	}
+
+	if len(result) != resultSize {
+		return nil, errInvalid
+	}
+
+	return result, nil
+}
+
+// GitSignature is an author or a committer of a git commit.
+type GitSignature struct {
+	Name  string
+	Email string
+	When  time.Time
+}
+
+// parseGitSignature parses "Name <email> <unix seconds> <timezone, e.g. +0100>".
+func parseGitSignature(s string) (GitSignature, error) {
+	emailStart, emailEnd := strings.LastIndexByte(s, '<'), strings.LastIndexByte(s, '>')
+	if emailStart < 0 || emailEnd < emailStart {
+		return GitSignature{}, fmt.Errorf("invalid signature: %q", s)
+	}
+
+	signature := GitSignature{
+		Name:  strings.TrimSpace(s[:emailStart]),
+		Email: s[emailStart+1 : emailEnd],
+	}
+
+	fields := strings.Fields(s[emailEnd+1:])
+	if len(fields) != 2 || len(fields[1]) != 5 {
+		return GitSignature{}, fmt.Errorf("invalid signature time: %q", s)
+	}
+	secs, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return GitSignature{}, fmt.Errorf("invalid signature time: %q", s)
+	}
+	hours, errHours := strconv.Atoi(fields[1][1:3])
+	minutes, errMinutes := strconv.Atoi(fields[1][3:5])
+	if errHours != nil || errMinutes != nil {
+		return GitSignature{}, fmt.Errorf("invalid signature timezone: %q", s)
+	}
+	zoneOffset := (hours*60 + minutes) * 60
+	if fields[1][0] == '-' {
+		zoneOffset = -zoneOffset
+	}
+
+	signature.When = time.Unix(secs, 0).In(time.FixedZone(fields[1], zoneOffset))
+	return signature, nil
+}
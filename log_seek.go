@@ -0,0 +1,225 @@
+package years
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// SeekLog returns a reader of the time-sorted log positioned at the first record whose time is at or after
+// the target (the reader is empty if there are none). The record is found via binary search over byte offsets,
+// so only O(log(size)) lines are read. Lines not having a timestamp (e.g. stack traces) are skipped while
+// searching, and positions are resynced to line boundaries.
+// Use (*io.SectionReader).Outer to get the offset of the found record.
+func SeekLog(r io.ReaderAt, size int64, extractor LineTimeExtractor, target time.Time) (*io.SectionReader, error) {
+	offset, err := seekLogOffset(r, size, extractor, target)
+	if err != nil {
+		return nil, err
+	}
+
+	return io.NewSectionReader(r, offset, size-offset), nil
+}
+
+// seekLogOffset returns the offset of the first record at or after the target (size if there are none).
+func seekLogOffset(r io.ReaderAt, size int64, extractor LineTimeExtractor, target time.Time) (int64, error) {
+	// Searching for the minimal position, whose next timestamped line is at or after the target:
+	// for sorted logs it's false for positions before the record and true for the rest.
+	lo, hi := int64(0), size
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		start, t, found, err := nextTimestampedLine(r, size, mid, extractor)
+		if err != nil {
+			return 0, err
+		}
+
+		if !found || !t.Before(target) {
+			hi = mid
+		} else {
+			// positions up to the found line have the same next line, so they are skipped at once
+			lo = start + 1
+		}
+	}
+
+	start, _, found, err := nextTimestampedLine(r, size, lo, extractor)
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return size, nil
+	}
+
+	return start, nil
+}
+
+// nextTimestampedLine finds the first line starting at or after pos, that has a timestamp.
+// It returns the line's offset and time (or found=false if there are none till the end).
+func nextTimestampedLine(
+	r io.ReaderAt, size int64, pos int64, extractor LineTimeExtractor,
+) (start int64, t time.Time, found bool, err error) {
+	start = pos
+	if pos > 0 {
+		// resync: unless pos is right after a newline, skip the rest of the current line
+		start = pos - 1
+	}
+	reader := bufio.NewReader(io.NewSectionReader(r, start, size-start))
+
+	if pos > 0 {
+		skipped, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return 0, time.Time{}, false, fmt.Errorf("could not read log: %w", err)
+		}
+		start += int64(len(skipped))
+	}
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return 0, time.Time{}, false, fmt.Errorf("could not read log: %w", err)
+		}
+		if len(line) > 0 {
+			if t, ok := extractor(line); ok {
+				return start, t, true, nil
+			}
+			start += int64(len(line))
+		}
+		if errors.Is(err, io.EOF) {
+			return size, time.Time{}, false, nil
+		}
+	}
+}
+
+// LogPosition is the position of a record in a log file found via Voyager.SeekLog.
+type LogPosition struct {
+	// Waypoint is the waypoint of the log file (or of the record itself for LogFileWaypoint trees).
+	Waypoint Waypoint
+	// Path is the path of the log file (in the file system of the tree, see NewWaypointFileFS).
+	Path string
+	// Offset is the byte offset of the record in the file.
+	Offset int64
+}
+
+// Open opens the log file positioned at the record.
+// Files are opened via the file system of the tree, so fs.FS-backed and archived files are supported as well.
+func (p *LogPosition) Open() (io.ReadCloser, error) {
+	f, err := openLogFile(p.Waypoint, p.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if seeker, ok := f.(io.Seeker); ok {
+		_, err = seeker.Seek(p.Offset, io.SeekStart)
+	} else {
+		// e.g. archived files are streams
+		_, err = io.CopyN(io.Discard, f, p.Offset)
+	}
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("could not seek log file: %w", err)
+	}
+
+	return f, nil
+}
+
+// fileWaypoint is a waypoint of a file that is opened via the file system it was read from
+// (see WaypointFile and TimeNamedWaypointFile).
+type fileWaypoint interface {
+	open() (fs.File, error)
+}
+
+// openLogFile opens the log file of the waypoint (via its file system, if it's a file waypoint).
+func openLogFile(w Waypoint, path string) (fs.File, error) {
+	var f fs.File
+	var err error
+	if fw, ok := w.(fileWaypoint); ok {
+		f, err = fw.open()
+	} else {
+		f, err = os.Open(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not open log file: %w", err)
+	}
+
+	return f, nil
+}
+
+// SeekLog finds the first log record at or after the given time (as a string, e.g. "10 minutes ago")
+// in a tree of time-sorted log files (e.g. files of TimeNamedWaypointFile named by their first record's time).
+// The file is the latest one starting at or before the time, then the record is found via binary search in it
+// (see SeekLog). If the file has no such records, the first record of the next file is the one.
+// For LogFileWaypoint trees records are known already, so the first one at or after the time is taken.
+// It returns nil if there is no such record.
+func (v *Voyager) SeekLog(to string, extractor LineTimeExtractor) (*LogPosition, error) {
+	target, err := v.parser.Parse("", to)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse time: %w", err)
+	}
+
+	files := make([]Waypoint, 0)
+	for w := range v.All(O_FUTURE(), O_LEAVES_ONLY()) {
+		if record, ok := w.(*LogLineWaypoint); ok {
+			if !record.Time().Before(target) {
+				return &LogPosition{Waypoint: record, Path: record.file.path, Offset: record.offset}, nil
+			}
+			continue
+		}
+		files = append(files, w)
+	}
+
+	// the latest file starting at or before the target (or the first one if all start after it)
+	first := 0
+	for i, file := range files {
+		if file.Time().After(target) {
+			break
+		}
+		first = i
+	}
+
+	for _, file := range files[first:] {
+		offset, size, err := seekLogFile(file, extractor, target)
+		if err != nil {
+			return nil, err
+		}
+		if offset < size {
+			return &LogPosition{Waypoint: file, Path: file.Identifier(), Offset: offset}, nil
+		}
+	}
+
+	return nil, nil //nolint:nilnil // nothing found is not an error
+}
+
+// seekLogFile returns the offset of the first record at or after the target in the log file, and its size.
+func seekLogFile(file Waypoint, extractor LineTimeExtractor, target time.Time) (offset int64, size int64, err error) {
+	f, err := openLogFile(file, file.Identifier())
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+
+	r, ok := f.(io.ReaderAt)
+	if ok {
+		stat, err := f.Stat()
+		if err != nil {
+			return 0, 0, fmt.Errorf("could not stat log file: %w", err)
+		}
+		size = stat.Size()
+	} else {
+		// files not supporting random access (e.g. archived ones) are read into memory
+		content, err := io.ReadAll(f)
+		if err != nil {
+			return 0, 0, fmt.Errorf("could not read log file: %w", err)
+		}
+		r, size = bytes.NewReader(content), int64(len(content))
+	}
+
+	offset, err = seekLogOffset(r, size, extractor, target)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return offset, size, nil
+}
@@ -0,0 +1,131 @@
+package years_test
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/amberpixels/years"
+	"github.com/expectto/be"
+)
+
+func TestSeekLog(t *testing.T) {
+	voyagerSetup(t)
+	start := time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC)
+	extractor := years.LineTimestamp(years.LayoutDateTime)
+
+	// a record every 10 seconds, every third one having a stack trace
+	var b strings.Builder
+	for i := range 500 {
+		fmt.Fprintf(&b, "%s INFO record %d\n", years.Format(start.Add(time.Duration(i)*10*time.Second), years.LayoutDateTime), i)
+		if i%3 == 0 {
+			b.WriteString("    at main.go:42\n    at main.go:7\n")
+		}
+	}
+	content := b.String()
+
+	seek := func(t *testing.T, target time.Time) string {
+		t.Helper()
+		r, err := years.SeekLog(strings.NewReader(content), int64(len(content)), extractor, target)
+		be.Require(t, err).To(be.Succeed())
+		rest, err := io.ReadAll(r)
+		be.Require(t, err).To(be.Succeed())
+		line, _, _ := strings.Cut(string(rest), "\n")
+		return line
+	}
+
+	be.Expect(t, seek(t, start.Add(-time.Hour))).To(be.Eq("2024-03-05 00:00:00 INFO record 0"))
+	be.Expect(t, seek(t, start)).To(be.Eq("2024-03-05 00:00:00 INFO record 0"))
+	be.Expect(t, seek(t, start.Add(time.Second))).To(be.Eq("2024-03-05 00:00:10 INFO record 1"))
+	be.Expect(t, seek(t, start.Add(1000*time.Second))).To(be.Eq("2024-03-05 00:16:40 INFO record 100"))
+	be.Expect(t, seek(t, start.Add(4991*time.Second))).To(be.Eq(""))
+
+	r, err := years.SeekLog(strings.NewReader(content), int64(len(content)), extractor, start.Add(10*time.Second))
+	be.Require(t, err).To(be.Succeed())
+	_, offset, _ := r.Outer()
+	be.Expect(t, offset).To(be.Eq(int64(strings.Index(content, "2024-03-05 00:00:10"))))
+}
+
+func TestVoyager_SeekLog(t *testing.T) {
+	voyagerSetup(t)
+	root := filepath.Join(t.TempDir(), "logs")
+	be.Require(t, os.MkdirAll(root, 0o755)).To(be.Succeed())
+	logs := map[string]string{
+		"2024-03-04.log": "2024-03-04 10:00:00 a\n2024-03-04 23:00:00 b\n",
+		"2024-03-05.log": "2024-03-05 09:00:00 c\n2024-03-05 12:00:00 d\n  trace\n2024-03-05 18:00:00 e\n",
+		"2024-03-06.log": "2024-03-06 08:00:00 f\n",
+	}
+	for name, content := range logs {
+		be.Require(t, os.WriteFile(filepath.Join(root, name), []byte(content), 0o600)).To(be.Succeed())
+	}
+
+	wf, err := years.NewTimeNamedWaypointFile(root, "2006-01-02.log")
+	be.Require(t, err).To(be.Succeed())
+	extractor := years.LineTimestamp(years.LayoutDateTime)
+
+	lineAt := func(t *testing.T, v *years.Voyager, to string) string {
+		t.Helper()
+		pos, err := v.SeekLog(to, extractor)
+		be.Require(t, err).To(be.Succeed())
+		if pos == nil {
+			return ""
+		}
+		f, err := pos.Open()
+		be.Require(t, err).To(be.Succeed())
+		defer f.Close()
+		rest, err := io.ReadAll(f)
+		be.Require(t, err).To(be.Succeed())
+		line, _, _ := strings.Cut(string(rest), "\n")
+		return filepath.Base(pos.Path) + ": " + line
+	}
+
+	v := years.NewVoyager(wf)
+	be.Expect(t, lineAt(t, v, "2024-03-05 11:00:00")).To(be.Eq("2024-03-05.log: 2024-03-05 12:00:00 d"))
+	be.Expect(t, lineAt(t, v, "2024-03-05 12:00:01")).To(be.Eq("2024-03-05.log: 2024-03-05 18:00:00 e"))
+	be.Expect(t, lineAt(t, v, "2024-03-05 19:00:00")).To(be.Eq("2024-03-06.log: 2024-03-06 08:00:00 f"))
+	be.Expect(t, lineAt(t, v, "2024-03-01 00:00:00")).To(be.Eq("2024-03-04.log: 2024-03-04 10:00:00 a"))
+	be.Expect(t, lineAt(t, v, "2024-03-07 00:00:00")).To(be.Eq(""))
+
+	t.Run("fs.FS files", func(t *testing.T) {
+		fsys := fstest.MapFS{}
+		for name, content := range logs {
+			fsys["logs/"+name] = &fstest.MapFile{Data: []byte(content)}
+		}
+		wf, err := years.NewTimeNamedWaypointFileFS(fsys, "logs", "2006-01-02.log")
+		be.Require(t, err).To(be.Succeed())
+
+		v := years.NewVoyager(wf)
+		be.Expect(t, lineAt(t, v, "2024-03-05 11:00:00")).To(be.Eq("2024-03-05.log: 2024-03-05 12:00:00 d"))
+		be.Expect(t, lineAt(t, v, "2024-03-05 19:00:00")).To(be.Eq("2024-03-06.log: 2024-03-06 08:00:00 f"))
+	})
+
+	t.Run("archived files", func(t *testing.T) {
+		archived := make([]archivedFile, 0, len(logs))
+		for name, content := range logs {
+			archived = append(archived, archivedFile{name: name, content: content})
+		}
+		archiveRoot := filepath.Join(t.TempDir(), "logs")
+		writeArchive(t, filepath.Join(archiveRoot, "2024-03.tar.gz"), archived...)
+		wf, err := years.NewTimeNamedWaypointFileWithOptions(archiveRoot, "2006-01/2006-01-02.log", years.WithArchives())
+		be.Require(t, err).To(be.Succeed())
+
+		v := years.NewVoyager(wf)
+		be.Expect(t, lineAt(t, v, "2024-03-05 12:00:01")).To(be.Eq("2024-03-05.log: 2024-03-05 18:00:00 e"))
+		be.Expect(t, lineAt(t, v, "2024-03-05 19:00:00")).To(be.Eq("2024-03-06.log: 2024-03-06 08:00:00 f"))
+	})
+
+	t.Run("log file waypoints", func(t *testing.T) {
+		lf, err := years.NewLogFileWaypoint(filepath.Join(root, "2024-03-05.log"), extractor)
+		be.Require(t, err).To(be.Succeed())
+
+		pos, err := years.NewVoyager(lf).SeekLog("2024-03-05 10:00:00", extractor)
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, pos.Offset).To(be.Eq(int64(len("2024-03-05 09:00:00 c\n"))))
+		be.Expect(t, pos.Waypoint.Identifier()).To(be.Eq(lf.Identifier() + ":2"))
+	})
+}
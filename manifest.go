@@ -0,0 +1,218 @@
+package years
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"sync"
+	"time"
+)
+
+// ManifestVersion is the version of the manifest format written by Manifest.
+const ManifestVersion = 1
+
+// Manifest is a snapshot of a waypoint tree, that can be saved (as JSON or gob) and loaded back
+// as a navigable tree (see Manifest.Waypoint), e.g. to avoid rescanning a large calendar on every start.
+type Manifest struct {
+	Version   int            `json:"version"`
+	CreatedAt time.Time      `json:"created_at"`
+	Root      *ManifestEntry `json:"root"`
+}
+
+// ManifestEntry is a waypoint of the manifest.
+type ManifestEntry struct {
+	Identifier string    `json:"id"`
+	Time       time.Time `json:"time,omitzero"`
+	Container  bool      `json:"container,omitempty"`
+
+	// Start and End are the span of the waypoint, if it's known (see SpanWaypoint)
+	Start time.Time `json:"start,omitzero"`
+	End   time.Time `json:"end,omitzero"`
+
+	// Unit and Layout are set for waypoints named by a layout (e.g. TimeNamedWaypointFile)
+	Unit   DateUnit `json:"unit,omitempty"`
+	Layout string   `json:"layout,omitempty"`
+
+	// TimeSource is the name of the source the time was taken from (see TimeSource)
+	TimeSource string `json:"time_source,omitempty"`
+
+	// ModTime is the modification time of file waypoints (used to detect stale entries)
+	ModTime time.Time `json:"mod_time,omitzero"`
+
+	Children []*ManifestEntry `json:"children,omitempty"`
+}
+
+// NewManifest takes a snapshot of the given waypoint tree. All children are read (even lazy ones).
+func NewManifest(w Waypoint) *Manifest {
+	return &Manifest{Version: ManifestVersion, CreatedAt: Now(), Root: newManifestEntry(w)}
+}
+
+func newManifestEntry(w Waypoint) *ManifestEntry {
+	entry := &ManifestEntry{Identifier: w.Identifier(), Time: w.Time(), Container: w.IsContainer()}
+
+	if span, ok := SpanOf(w); ok {
+		entry.Start, entry.End = span.Start, span.End
+	}
+	if uw, ok := w.(unitWaypoint); ok {
+		entry.Unit = uw.Unit()
+	}
+
+	var wf *WaypointFile
+	switch v := w.(type) {
+	case *WaypointFile:
+		wf = v
+	case *TimeNamedWaypointFile:
+		wf = v.WaypointFile
+		entry.Layout = v.layout
+		if v.layout != "" {
+			entry.TimeSource = TimeSourceLayout
+		}
+	case *WaypointString:
+		entry.Layout = v.layout
+	case *ManifestWaypoint:
+		entry.Layout, entry.TimeSource, entry.ModTime = v.entry.Layout, v.entry.TimeSource, v.entry.ModTime
+	}
+	if wf != nil {
+		if wf.timeSource != nil {
+			entry.TimeSource = wf.timeSource.Name()
+		}
+		// entries of archives (see WithArchives) are not files, their archive's time tells if they are stale
+		if _, inArchive := wf.fileInfo.(*archiveEntry); !inArchive {
+			entry.ModTime = wf.fileInfo.ModTime()
+		}
+	}
+
+	if entry.Container {
+		for _, child := range w.Children() {
+			entry.Children = append(entry.Children, newManifestEntry(child))
+		}
+	}
+
+	return entry
+}
+
+// WriteJSON writes the manifest as JSON.
+func (m *Manifest) WriteJSON(w io.Writer) error {
+	if err := json.NewEncoder(w).Encode(m); err != nil {
+		return fmt.Errorf("could not encode manifest: %w", err)
+	}
+	return nil
+}
+
+// WriteGob writes the manifest in the compact binary gob format (see encoding/gob).
+func (m *Manifest) WriteGob(w io.Writer) error {
+	if err := gob.NewEncoder(w).Encode(m); err != nil {
+		return fmt.Errorf("could not encode manifest: %w", err)
+	}
+	return nil
+}
+
+// ReadManifestJSON reads the manifest written via Manifest.WriteJSON.
+func ReadManifestJSON(r io.Reader) (*Manifest, error) {
+	var m Manifest
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, fmt.Errorf("could not decode manifest: %w", err)
+	}
+	return &m, m.check()
+}
+
+// ReadManifestGob reads the manifest written via Manifest.WriteGob.
+func ReadManifestGob(r io.Reader) (*Manifest, error) {
+	var m Manifest
+	if err := gob.NewDecoder(r).Decode(&m); err != nil {
+		return nil, fmt.Errorf("could not decode manifest: %w", err)
+	}
+	return &m, m.check()
+}
+
+// check ensures the read manifest is supported.
+func (m *Manifest) check() error {
+	if m.Version != ManifestVersion {
+		return fmt.Errorf("unsupported manifest version: %d", m.Version)
+	}
+	if m.Root == nil {
+		return errors.New("manifest has no root")
+	}
+	return nil
+}
+
+// Waypoint returns the tree of the manifest as a waypoint.
+func (m *Manifest) Waypoint() *ManifestWaypoint { return &ManifestWaypoint{entry: m.Root} }
+
+// StaleEntries returns file entries that are stale: the file is modified (or removed) after the snapshot.
+// As adding or removing a file modifies its directory, new files are detected via their stale directory.
+// Entries not being files (having no ModTime) are never stale.
+func (m *Manifest) StaleEntries() []*ManifestEntry { return m.staleEntries(osFileSystem{}) }
+
+// StaleEntriesFS is StaleEntries for manifests of trees built over the given file system (see NewWaypointFileFS).
+func (m *Manifest) StaleEntriesFS(fsys fs.FS) []*ManifestEntry {
+	return m.staleEntries(ioFileSystem{fsys: fsys})
+}
+
+func (m *Manifest) staleEntries(fsys fileSystem) []*ManifestEntry {
+	stale := make([]*ManifestEntry, 0)
+
+	var check func(entry *ManifestEntry)
+	check = func(entry *ManifestEntry) {
+		if !entry.ModTime.IsZero() {
+			info, err := fsys.Stat(entry.Identifier)
+			if err != nil || !info.ModTime().Equal(entry.ModTime) {
+				stale = append(stale, entry)
+			}
+		}
+		for _, child := range entry.Children {
+			check(child)
+		}
+	}
+	check(m.Root)
+
+	return stale
+}
+
+// ManifestWaypoint is a Waypoint implementation for an entry of a manifest (see Manifest.Waypoint).
+type ManifestWaypoint struct {
+	entry *ManifestEntry
+
+	children []Waypoint
+	once     sync.Once
+}
+
+func (w *ManifestWaypoint) Time() time.Time    { return w.entry.Time }
+func (w *ManifestWaypoint) Identifier() string { return w.entry.Identifier }
+func (w *ManifestWaypoint) IsContainer() bool  { return w.entry.Container }
+
+// Children returns waypoints of the entry's children (they are created on the first call).
+func (w *ManifestWaypoint) Children() []Waypoint {
+	w.once.Do(func() {
+		w.children = make([]Waypoint, len(w.entry.Children))
+		for i, child := range w.entry.Children {
+			w.children[i] = &ManifestWaypoint{entry: child}
+		}
+	})
+	return w.children
+}
+
+// Start returns the beginning of the span the waypoint stands for (the time if the span is unknown).
+func (w *ManifestWaypoint) Start() time.Time {
+	if w.entry.Start.IsZero() {
+		return w.entry.Time
+	}
+	return w.entry.Start
+}
+
+// End returns the end (exclusive) of the span the waypoint stands for (the time if the span is unknown).
+func (w *ManifestWaypoint) End() time.Time {
+	if w.entry.End.IsZero() {
+		return w.entry.Time
+	}
+	return w.entry.End
+}
+
+// Unit returns the date unit of the waypoint (UnitUndefined if it's unknown).
+func (w *ManifestWaypoint) Unit() DateUnit { return w.entry.Unit }
+
+// Entry returns the manifest entry of the waypoint.
+func (w *ManifestWaypoint) Entry() *ManifestEntry { return w.entry }
@@ -0,0 +1,117 @@
+package years_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amberpixels/years"
+	"github.com/expectto/be"
+)
+
+func TestManifest(t *testing.T) {
+	voyagerSetup(t, "2006-01-02")
+	root := filepath.Join(t.TempDir(), "calendar")
+	writeFiles(t, root,
+		"2024/Feb/2024-02-29.txt",
+		"2024/Mar/2024-03-01.txt",
+		"2024/Mar/2024-03-05.txt",
+		"notes.txt",
+	)
+
+	wf, err := years.NewTimeNamedWaypointFile(root, "2006/Jan/2006-01-02.txt")
+	be.Require(t, err).To(be.Succeed())
+	original := years.NewVoyager(wf)
+
+	manifest := years.NewManifest(wf)
+	be.Expect(t, manifest.Version).To(be.Eq(years.ManifestVersion))
+	be.Expect(t, manifest.CreatedAt).To(be.Eq(years.Now()))
+
+	roundTrips := map[string]func(t *testing.T) *years.Manifest{
+		"json": func(t *testing.T) *years.Manifest {
+			var buf bytes.Buffer
+			be.Require(t, manifest.WriteJSON(&buf)).To(be.Succeed())
+			restored, err := years.ReadManifestJSON(&buf)
+			be.Require(t, err).To(be.Succeed())
+			return restored
+		},
+		"gob": func(t *testing.T) *years.Manifest {
+			var buf bytes.Buffer
+			be.Require(t, manifest.WriteGob(&buf)).To(be.Succeed())
+			restored, err := years.ReadManifestGob(&buf)
+			be.Require(t, err).To(be.Succeed())
+			return restored
+		},
+	}
+	for name, roundTrip := range roundTrips {
+		t.Run(name, func(t *testing.T) {
+			restored := roundTrip(t)
+			v := years.NewVoyager(restored.Waypoint())
+
+			be.Expect(t, collectTraverse(t, v, years.O_FUTURE())).To(be.Eq(collectTraverse(t, original, years.O_FUTURE())))
+			be.Expect(t, collectTraverse(t, v, years.O_NON_CALENDAR())).
+				To(be.Eq(collectTraverse(t, original, years.O_NON_CALENDAR())))
+
+			w, err := v.Navigate("2024-03-05")
+			be.Require(t, err).To(be.Succeed())
+			be.Require(t, w).NotTo(be.Nil())
+			be.Expect(t, w.Identifier()).To(be.Eq(filepath.Join(root, "2024", "Mar", "2024-03-05.txt")))
+
+			entry := w.(*years.ManifestWaypoint).Entry()
+			be.Expect(t, entry.Unit).To(be.Eq(years.Day))
+			be.Expect(t, entry.Layout).To(be.Eq("2006/Jan/2006-01-02.txt"))
+			be.Expect(t, entry.TimeSource).To(be.Eq(years.TimeSourceLayout))
+
+			// months keep their spans
+			var month years.Period
+			for w := range v.All(years.O_CONTAINERS_ONLY()) {
+				if filepath.Base(w.Identifier()) == "Mar" {
+					month, _ = years.SpanOf(w)
+				}
+			}
+			be.Expect(t, month.End.Equal(time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC))).To(be.True())
+
+			be.Expect(t, restored.StaleEntries()).To(be.Empty())
+		})
+	}
+
+	t.Run("stale entries", func(t *testing.T) {
+		changed := filepath.Join(root, "2024", "Mar", "2024-03-01.txt")
+		later := time.Now().Add(time.Hour)
+		be.Require(t, os.Chtimes(changed, later, later)).To(be.Succeed())
+		be.Require(t, os.Remove(filepath.Join(root, "notes.txt"))).To(be.Succeed())
+
+		stale := make([]string, 0)
+		for _, entry := range manifest.StaleEntries() {
+			stale = append(stale, strings.TrimPrefix(entry.Identifier, root))
+		}
+		be.Expect(t, sorted(stale)).To(be.Eq(sorted([]string{
+			"", // root has lost notes.txt
+			filepath.Join(string(os.PathSeparator)+"2024", "Mar", "2024-03-01.txt"),
+			string(os.PathSeparator) + "notes.txt",
+		})))
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		_, err := years.ReadManifestJSON(strings.NewReader(`{"version": 100, "root": {"id": "x"}}`))
+		be.Expect(t, err).To(be.HaveOccurred())
+	})
+}
+
+func TestManifest_Group(t *testing.T) {
+	voyagerSetup(t, "2006-01-02")
+
+	group := years.NewWaypointGroup("dates", years.WaypointsFromStrings([]string{"2024-03-05", "2024-03-01"})...)
+	var buf bytes.Buffer
+	be.Require(t, years.NewManifest(group).WriteJSON(&buf)).To(be.Succeed())
+	restored, err := years.ReadManifestJSON(&buf)
+	be.Require(t, err).To(be.Succeed())
+
+	be.Expect(t, collectTraverse(t, years.NewVoyager(restored.Waypoint()), years.O_FUTURE())).
+		To(be.Eq([]string{"2024-03-01", "2024-03-05"}))
+	// not files, so never stale
+	be.Expect(t, restored.StaleEntries()).To(be.Empty())
+}
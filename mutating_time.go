@@ -73,6 +73,14 @@ func (mt *MutatingTime) TruncateToMonth() *MutatingTime {
 	return mt
 }
 
+// TruncateToQuarter moves the time to the first day of its quarter
+// (January, April, July or October) at 00:00:00.
+func (mt *MutatingTime) TruncateToQuarter() *MutatingTime {
+	quarterStartMonth := time.Month((int(mt.t.Month())-1)/monthsInQuarter*monthsInQuarter + 1)
+	*mt.t = time.Date(mt.t.Year(), quarterStartMonth, 1, 0, 0, 0, 0, mt.t.Location())
+	return mt
+}
+
 // TruncateToYear moves the time to January 1st of its year at 00:00:00.
 func (mt *MutatingTime) TruncateToYear() *MutatingTime {
 	*mt.t = time.Date(mt.t.Year(), 1, 1, 0, 0, 0, 0, mt.t.Location())
@@ -59,6 +59,16 @@ func TestMutatingTime_TruncateToMonth(t *testing.T) {
 	be.Expect(t, t0).To(be.Eq(time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)))
 }
 
+func TestMutatingTime_TruncateToQuarter(t *testing.T) {
+	t0 := time.Date(2025, time.May, 30, 13, 45, 59, 1, time.UTC)
+	years.Mutate(&t0).TruncateToQuarter()
+	be.Expect(t, t0).To(be.Eq(time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)))
+
+	t1 := time.Date(2025, time.December, 31, 23, 59, 59, 0, time.UTC)
+	years.Mutate(&t1).TruncateToQuarter()
+	be.Expect(t, t1).To(be.Eq(time.Date(2025, time.October, 1, 0, 0, 0, 0, time.UTC)))
+}
+
 func TestMutatingTime_TruncateToYear(t *testing.T) {
 	t0 := time.Date(2025, time.April, 30, 13, 45, 59, 1, time.UTC)
 	years.Mutate(&t0).TruncateToYear()
@@ -103,6 +103,13 @@ func NewParser(options ...ParserOption) *Parser {
 	return p
 }
 
+// withoutEpochs returns a copy of the parser that doesn't accept numeric timestamps.
+func (p *Parser) withoutEpochs() *Parser {
+	c := *p
+	c.acceptUnixSeconds, c.acceptUnixMilli, c.acceptUnixMicro, c.acceptUnixNano = false, false, false, false
+	return &c
+}
+
 // DefaultParser makes a default parser
 //
 //nolint:gochecknoglobals // it's ok
@@ -172,10 +179,13 @@ func (p *Parser) ParseEpoch(v int64) (time.Time, bool, error) {
 }
 
 // Parse parses time from given value using given layout (or using all parser's accepted layouts if layout is empty).
+// Numeric values are taken as unix timestamps (see AcceptUnixSeconds, etc) unless a Go layout is given explicitly:
+// e.g. "2024" is the year 2024 for "2006" layout (as for "2024" directory of a time named tree), not a timestamp.
 func (p *Parser) Parse(layout string, value string) (time.Time, error) {
 	// Shorthand: if possible, try to parse as a numeric timestamp:
+	// (unless a Go layout is given explicitly, e.g. "2024" for "2006" layout is a year, not a timestamp)
 	digits, parseIntErr := strconv.ParseInt(value, 10, 64)
-	isNumericValue := parseIntErr == nil
+	isNumericValue := parseIntErr == nil && !isGoLayout(layout)
 
 	if isNumericValue {
 		if p.acceptUnixSeconds || p.acceptUnixMilli || p.acceptUnixMicro || p.acceptUnixNano {
@@ -251,6 +261,16 @@ func (p *Parser) Parse(layout string, value string) (time.Time, error) {
 	return time.Time{}, errors.New("unable to parse time")
 }
 
+// isGoLayout checks if the given layout is a (non-empty) Go-format layout.
+func isGoLayout(layout string) bool {
+	if layout == "" {
+		return false
+	}
+
+	layoutDetails := ParseLayout(layout)
+	return layoutDetails != nil && layoutDetails.Format == LayoutFormatGo
+}
+
 // JustParse is a shortcut for Parse("", value) (so using all parser's accepted layouts).
 func (p *Parser) JustParse(value string) (time.Time, error) {
 	return p.Parse("", value)
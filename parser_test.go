@@ -78,3 +78,12 @@ func TestParser_JustParseAliases(t *testing.T) {
 	be.Require(t, err).To(be.Succeed())
 	be.Expect(t, tomorrow.String()).To(be.Eq(`2024-03-02 00:00:00 +0000 UTC`))
 }
+
+func TestParser_ParseNumericValueWithGoLayout(t *testing.T) {
+	t.Cleanup(years.ResetParserDefaults)
+
+	// numeric value is not considered a timestamp when a Go layout is given explicitly
+	parsedTime, err := years.DefaultParser().Parse("2006", "2024")
+	be.Require(t, err).To(be.Succeed())
+	be.Expect(t, parsedTime).To(be.Eq(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)))
+}
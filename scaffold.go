@@ -0,0 +1,159 @@
+package years
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// LayoutPath returns the path (relative to the root) of the time for the given full layout,
+// e.g. "2024/Mar/2024-03-05.txt" for "2006/Jan/2006-01-02.txt" layout.
+// It's the inverse of NewTimeNamedWaypointFile: its layout parts are formatted one by one,
+// so timestamp layouts (e.g. "foobar_U@000.log") are supported as well.
+func LayoutPath(fullLayout string, t time.Time) string {
+	parts := strings.Split(fullLayout, string(os.PathSeparator))
+	for i, part := range parts {
+		parts[i] = formatLayoutPart(part, t)
+	}
+	return filepath.Join(parts...)
+}
+
+// formatLayoutPart formats the time via the layout part, replacing its timestamp part (if any) with the timestamp.
+func formatLayoutPart(layout string, t time.Time) string {
+	if !strings.Contains(layout, LayoutTimestampSeconds) {
+		return t.Format(layout)
+	}
+
+	// the longest token goes first, as shorter ones are its prefixes
+	timestamps := []struct {
+		token string
+		value int64
+	}{
+		{LayoutTimestampNanoseconds, t.UnixNano()},
+		{LayoutTimestampMicroseconds, t.UnixMicro()},
+		{LayoutTimestampMilliseconds, t.UnixMilli()},
+		{LayoutTimestampSeconds, t.Unix()},
+	}
+	for _, timestamp := range timestamps {
+		if strings.Contains(layout, timestamp.token) {
+			return strings.Replace(layout, timestamp.token, strconv.FormatInt(timestamp.value, 10), 1)
+		}
+	}
+
+	return layout
+}
+
+// ScaffoldData is the data scaffolding templates are executed with (see WithScaffoldTemplate).
+type ScaffoldData struct {
+	// Time is the time the file is scaffolded for
+	Time time.Time
+	// Path is the path of the file
+	Path string
+}
+
+// scaffoldConfig holds configuration for scaffolding.
+type scaffoldConfig struct {
+	parser     *Parser
+	createFile bool
+	template   string
+}
+
+// ScaffoldOption defines functional options for Scaffold.
+type ScaffoldOption func(*scaffoldConfig)
+
+// WithScaffoldParser opts to parse times via the given parser (the default one is used otherwise).
+func WithScaffoldParser(parser *Parser) ScaffoldOption {
+	return func(c *scaffoldConfig) { c.parser = parser }
+}
+
+// WithScaffoldFile opts to create the (empty) file as well, not just its directories.
+func WithScaffoldFile() ScaffoldOption {
+	return func(c *scaffoldConfig) { c.createFile = true }
+}
+
+// WithScaffoldTemplate opts to create the file from the given text/template executed with ScaffoldData,
+// e.g. "# {{ .Time.Format \"Monday, January 2\" }}\n".
+func WithScaffoldTemplate(tmpl string) ScaffoldOption {
+	return func(c *scaffoldConfig) {
+		c.createFile = true
+		c.template = tmpl
+	}
+}
+
+// Scaffold creates missing directories for the file of the given time (as a string or an alias, e.g. "today")
+// under the root, following the full layout (see LayoutPath). It returns the path of the file.
+// The file itself is created with WithScaffoldFile or WithScaffoldTemplate only. Existing files are kept as is.
+func Scaffold(root string, fullLayout string, at string, opts ...ScaffoldOption) (string, error) {
+	config := newScaffoldConfig(opts...)
+
+	t, err := config.parser.Parse("", at)
+	if err != nil {
+		return "", fmt.Errorf("could not parse time: %w", err)
+	}
+
+	return scaffold(root, fullLayout, t, config)
+}
+
+// ScaffoldTime is Scaffold for the given time.
+func ScaffoldTime(root string, fullLayout string, t time.Time, opts ...ScaffoldOption) (string, error) {
+	return scaffold(root, fullLayout, t, newScaffoldConfig(opts...))
+}
+
+func newScaffoldConfig(opts ...ScaffoldOption) *scaffoldConfig {
+	config := &scaffoldConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	if config.parser == nil {
+		// Default parser is used. Use years.SetParserDefaults to configure parsing
+		config.parser = NewParser()
+	}
+
+	return config
+}
+
+func scaffold(root string, fullLayout string, t time.Time, config *scaffoldConfig) (string, error) {
+	path := filepath.Join(root, LayoutPath(fullLayout, t))
+
+	// the content is rendered first, so nothing is created if the template is invalid
+	var content strings.Builder
+	if config.createFile && config.template != "" {
+		tmpl, err := template.New(filepath.Base(path)).Parse(config.template)
+		if err != nil {
+			return "", fmt.Errorf("could not parse template: %w", err)
+		}
+		if err := tmpl.Execute(&content, ScaffoldData{Time: t, Path: path}); err != nil {
+			return "", fmt.Errorf("could not execute template: %w", err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("could not create directories: %w", err)
+	}
+	if !config.createFile {
+		return path, nil
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if errors.Is(err, fs.ErrExist) {
+		return path, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("could not create file: %w", err)
+	}
+	if _, err := f.WriteString(content.String()); err != nil {
+		_ = f.Close()
+		return "", fmt.Errorf("could not write file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("could not write file: %w", err)
+	}
+
+	return path, nil
+}
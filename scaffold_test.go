@@ -0,0 +1,97 @@
+package years_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/amberpixels/years"
+	"github.com/expectto/be"
+)
+
+func TestLayoutPath(t *testing.T) {
+	at := time.Date(2024, time.March, 5, 14, 30, 59, 123_000_000, time.UTC)
+
+	be.Expect(t, years.LayoutPath("2006/Jan/2006-01-02.txt", at)).To(be.Eq(filepath.Join("2024", "Mar", "2024-03-05.txt")))
+	be.Expect(t, years.LayoutPath("2006/01", at)).To(be.Eq(filepath.Join("2024", "03")))
+	be.Expect(t, years.LayoutPath("foobar_U@.log", at)).To(be.Eq("foobar_1709649059.log"))
+	be.Expect(t, years.LayoutPath("foobar_U@000.log", at)).To(be.Eq("foobar_1709649059123.log"))
+	be.Expect(t, years.LayoutPath("2006/U@000000000", at)).To(be.Eq(filepath.Join("2024", "1709649059123000000")))
+}
+
+func TestScaffold(t *testing.T) {
+	voyagerSetup(t)
+	const layout = "2006/Jan/2006-01-02.txt"
+
+	t.Run("directories only", func(t *testing.T) {
+		root := filepath.Join(t.TempDir(), "calendar")
+
+		path, err := years.Scaffold(root, layout, "today")
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, path).To(be.Eq(filepath.Join(root, "2024", "Mar", "2024-03-05.txt")))
+
+		stat, err := os.Stat(filepath.Dir(path))
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, stat.IsDir()).To(be.True())
+		_, err = os.Stat(path)
+		be.Expect(t, err).To(be.HaveOccurred())
+	})
+
+	t.Run("template", func(t *testing.T) {
+		root := filepath.Join(t.TempDir(), "calendar")
+		tmpl := years.WithScaffoldTemplate("# {{ .Time.Format \"Monday, January 2\" }}\n")
+
+		path, err := years.Scaffold(root, layout, "yesterday", tmpl)
+		be.Require(t, err).To(be.Succeed())
+		content, err := os.ReadFile(path)
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, string(content)).To(be.Eq("# Monday, March 4\n"))
+
+		// existing files are kept
+		be.Require(t, os.WriteFile(path, []byte("edited"), 0o600)).To(be.Succeed())
+		_, err = years.Scaffold(root, layout, "yesterday", tmpl)
+		be.Require(t, err).To(be.Succeed())
+		content, err = os.ReadFile(path)
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, string(content)).To(be.Eq("edited"))
+
+		// scaffolded files are read back via the same layout
+		wf, err := years.NewTimeNamedWaypointFile(root, layout)
+		be.Require(t, err).To(be.Succeed())
+		w, err := years.NewVoyager(wf).Navigate("yesterday")
+		be.Require(t, err).To(be.Succeed())
+		be.Require(t, w).NotTo(be.Nil())
+		be.Expect(t, w.Identifier()).To(be.Eq(path))
+	})
+
+	t.Run("timestamp layout", func(t *testing.T) {
+		root := filepath.Join(t.TempDir(), "logs")
+		at := time.Date(2024, time.March, 5, 10, 0, 0, 0, time.UTC)
+
+		path, err := years.ScaffoldTime(root, "app_U@.log", at, years.WithScaffoldFile())
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, path).To(be.Eq(filepath.Join(root, "app_1709632800.log")))
+
+		wf, err := years.NewTimeNamedWaypointFile(root, "app_U@.log")
+		be.Require(t, err).To(be.Succeed())
+		be.Require(t, wf.Children()).To(be.HaveLength(1))
+		be.Expect(t, wf.Children()[0].Time().Equal(at)).To(be.True())
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		root := filepath.Join(t.TempDir(), "calendar")
+
+		_, err := years.Scaffold(root, layout, "not a time")
+		be.Expect(t, err).To(be.HaveOccurred())
+
+		_, err = years.Scaffold(root, layout, "today", years.WithScaffoldTemplate("{{ .Unknown }}"))
+		be.Expect(t, err).To(be.HaveOccurred())
+		_, err = years.Scaffold(root, layout, "today", years.WithScaffoldTemplate("{{ .Time "))
+		be.Expect(t, err).To(be.HaveOccurred())
+
+		// nothing is created on failures
+		_, err = os.Stat(root)
+		be.Expect(t, err).To(be.HaveOccurred())
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"regexp"
 	"slices"
 	"strings"
+	"time"
 )
 
 // DateUnit stays for the unit of a date like Day/Month/Year/etc.
@@ -14,9 +15,9 @@ const (
 	// Day as day of the month
 	// TODO(nice-to-have) support day of the week + day of the year.
 	Day  DateUnit = 1 << (iota - 1)
-	Week          // not supported yet
+	Week          // not supported in layouts yet
 	Month
-	Quarter // not supported yet
+	Quarter // not supported in layouts yet
 	Year
 
 	// UnixSecond as well as UnixMillisecond, UnixMicrosecond, UnixNanosecond
@@ -34,10 +35,12 @@ func (du DateUnit) String() string {
 
 	case Day:
 		return "day"
+	case Week:
+		return "week"
 	case Month:
 		return "month"
-	case Week, Quarter:
-		panic("not-implemented") // todo
+	case Quarter:
+		return "quarter"
 	case Year:
 		return "year"
 	case UnixSecond:
@@ -55,24 +58,83 @@ func (du DateUnit) String() string {
 
 func (du DateUnit) Defined() bool { return du != UnitUndefined }
 
+// truncate returns the start of the unit's period that contains t.
+// weekStartsOn is used for Week unit only.
+func (du DateUnit) truncate(t time.Time, weekStartsOn time.Weekday) time.Time {
+	switch du {
+	case Day:
+		return Mutate(&t).TruncateToDay().Time()
+	case Week:
+		return Mutate(&t).TruncateToWeek(weekStartsOn).Time()
+	case Month:
+		return Mutate(&t).TruncateToMonth().Time()
+	case Quarter:
+		return Mutate(&t).TruncateToQuarter().Time()
+	case Year:
+		return Mutate(&t).TruncateToYear().Time()
+	case UnixSecond:
+		return t.Truncate(time.Second)
+	case UnixMillisecond:
+		return t.Truncate(time.Millisecond)
+	case UnixMicrosecond:
+		return t.Truncate(time.Microsecond)
+	case UnixNanosecond:
+		return t
+	case UnitUndefined:
+		fallthrough
+	default:
+		panic("truncate: unsupported DateUnit: " + du.String())
+	}
+}
+
+// add returns t moved by n units.
+func (du DateUnit) add(t time.Time, n int) time.Time {
+	switch du {
+	case Day:
+		return t.AddDate(0, 0, n)
+	case Week:
+		return t.AddDate(0, 0, n*daysInWeek)
+	case Month:
+		return t.AddDate(0, n, 0)
+	case Quarter:
+		return t.AddDate(0, n*monthsInQuarter, 0)
+	case Year:
+		return t.AddDate(n, 0, 0)
+	case UnixSecond:
+		return t.Add(time.Duration(n) * time.Second)
+	case UnixMillisecond:
+		return t.Add(time.Duration(n) * time.Millisecond)
+	case UnixMicrosecond:
+		return t.Add(time.Duration(n) * time.Microsecond)
+	case UnixNanosecond:
+		return t.Add(time.Duration(n))
+	case UnitUndefined:
+		fallthrough
+	default:
+		panic("add: unsupported DateUnit: " + du.String())
+	}
+}
+
 // DateUnitsDict holds all available DateUnits.
 //
 //nolint:gochecknoglobals // it's ok
 var DateUnitsDict = struct {
-	Day   DateUnit
-	Month DateUnit
-	Year  DateUnit
+	Day     DateUnit
+	Week    DateUnit
+	Month   DateUnit
+	Quarter DateUnit
+	Year    DateUnit
 
 	UnixSecond      DateUnit
 	UnixMillisecond DateUnit
 	UnixMicrosecond DateUnit
 	UnixNanosecond  DateUnit
 }{
-	Day:   Day,
-	Month: Month,
-	Year:  Year,
-
-	// TODO: support week and quarter
+	Day:     Day,
+	Week:    Week,
+	Month:   Month,
+	Quarter: Quarter,
+	Year:    Year,
 
 	UnixSecond:      UnixSecond,
 	UnixMillisecond: UnixMillisecond,
@@ -218,8 +280,8 @@ func ParseLayout(layout string) *LayoutDetails {
 	return result
 }
 
-// find position (start,end) of the timestamp part in the layout (e.g. `U0.` or `U0.000` etc ).
-// e.g. `FileName_U0.txt` -> [10, 13].
+// find position (start,end) of the timestamp part in the layout (e.g. `U@` or `U@000` etc ).
+// e.g. `FileName_U@.txt` -> [9, 11].
 func findTimestampPart(layout string) (int, int) {
 	if !strings.Contains(layout, LayoutTimestampSeconds) {
 		return 0, 0
@@ -236,7 +298,30 @@ func findTimestampPart(layout string) (int, int) {
 	case strings.Contains(layout, LayoutTimestampMilliseconds):
 		start = strings.Index(layout, LayoutTimestampMilliseconds)
 		end = start + len(LayoutTimestampMilliseconds)
+	default:
+		start = strings.Index(layout, LayoutTimestampSeconds)
+		end = start + len(LayoutTimestampSeconds)
 	}
 
 	return start, end
 }
+
+// spanUnit returns the unit of the time span a value of the given layout stands for,
+// e.g. Month for "2006/Jan" (a whole month). It's UnitUndefined for invalid layouts and for
+// layouts having clock parts (e.g. "2006-01-02 15:04"), as their values stand for instants.
+func spanUnit(layout string) DateUnit {
+	layoutDetails := ParseLayout(layout)
+	if layoutDetails == nil {
+		return UnitUndefined
+	}
+
+	if layoutDetails.Format == LayoutFormatGo {
+		for _, clockPart := range []string{"15", "03", "04", "05", "PM", "pm"} {
+			if strings.Contains(layout, clockPart) {
+				return UnitUndefined
+			}
+		}
+	}
+
+	return layoutDetails.MinimalUnit
+}
@@ -1,8 +1,15 @@
 package years
 
 import (
+	"cmp"
+	"context"
+	"errors"
 	"fmt"
+	"iter"
+	"path/filepath"
 	"slices"
+	"strings"
+	"time"
 )
 
 // Voyager is a wrapper for a waypoint that allows for traversing through it.
@@ -24,6 +31,20 @@ func NewVoyager(root Waypoint, parserArg ...*Parser) *Voyager {
 	return v
 }
 
+// liveWaypoint is a root waypoint standing for a tree that changes over time (see Watcher.Voyager).
+// Voyager takes its current tree once per traversal, so the traversal sees a consistent tree.
+type liveWaypoint interface {
+	current() Waypoint
+}
+
+// rootWaypoint returns the waypoint to traverse (the current tree if the root is a live one).
+func (v *Voyager) rootWaypoint() Waypoint {
+	if live, ok := v.root.(liveWaypoint); ok {
+		return live.current()
+	}
+	return v.root
+}
+
 // Traversing means walking through voyager's prepared tree.
 
 // TraverseDirection is a direction for traversing (e.g. past or future).
@@ -43,10 +64,86 @@ const (
 	TraverseAllNodes       TraverseNodesMode = "all"
 )
 
+// RangeBounds specifies which bounds of a time range are inclusive.
+// Notation follows the math one: "[" and "]" stand for inclusive bounds, "(" and ")" for exclusive ones.
+type RangeBounds string
+
+const (
+	RangeInclusive     RangeBounds = "[]"
+	RangeExclusive     RangeBounds = "()"
+	RangeIncludeFrom   RangeBounds = "[)"
+	RangeIncludeTo     RangeBounds = "(]"
+	defaultRangeBounds             = RangeInclusive
+)
+
+// contains checks if t is in the range between from and to respecting the bounds.
+func (b RangeBounds) contains(from, to, t time.Time) bool {
+	switch b {
+	case RangeInclusive:
+		return !t.Before(from) && !t.After(to)
+	case RangeExclusive:
+		return t.After(from) && t.Before(to)
+	case RangeIncludeFrom:
+		return !t.Before(from) && t.Before(to)
+	case RangeIncludeTo:
+		return t.After(from) && !t.After(to)
+	default:
+		panic("invalid range bounds: " + b)
+	}
+}
+
+// overlaps checks if the span overlaps the range between from and to respecting the bounds.
+// As the span's end is exclusive, only the "to" bound matters: the span overlaps if it has
+// a point after "from" (it ends after it) and a point before "to" (it starts before it, or at it if inclusive).
+func (b RangeBounds) overlaps(from, to time.Time, span Period) bool {
+	if !span.End.After(from) {
+		return false
+	}
+
+	switch b {
+	case RangeInclusive, RangeIncludeTo:
+		return !span.Start.After(to)
+	case RangeExclusive, RangeIncludeFrom:
+		return span.Start.Before(to)
+	default:
+		panic("invalid range bounds: " + b)
+	}
+}
+
 type traverseConfig struct {
 	direction               TraverseDirection
 	nodesMode               TraverseNodesMode
 	includeNonCalendarNodes bool
+
+	// bounds are used by range queries (see Voyager.Between)
+	bounds RangeBounds
+
+	// offset is a number of traversable nodes to be skipped before calling the callback
+	offset int
+	// limit is a max number of traversable nodes to be passed to the callback (0 means no limit)
+	limit int
+
+	// filters are additional checks a node has to pass to be traversable
+	filters []func(node *traverseNode) bool
+
+	// tieBreaker compares waypoints having the same time.
+	// If not set (or it considers waypoints equal), the tree order (parents first) is used.
+	tieBreaker func(a, b Waypoint) int
+
+	// onEnter and onLeave are hooks called around descending into containers (tree-order traversal only)
+	onEnter func(node TreeNode)
+	onLeave func(node TreeNode)
+}
+
+// traverseNode holds a waypoint together with its position in the traversed tree.
+type traverseNode struct {
+	waypoint Waypoint
+	parent   *traverseNode
+
+	// depth is the nesting level of the waypoint: 0 for the root, 1 for its children, etc
+	depth int
+	// index is the position of the waypoint in the depth-first (parents first) tree order
+	index int
 }
 
 // defaultTraverseConfig is Future->Past + all type of nodes.
@@ -54,15 +151,23 @@ func defaultTraverseConfig() traverseConfig {
 	return traverseConfig{
 		direction: TraverseDirectionPast,
 		nodesMode: TraverseAllNodes,
+		bounds:    defaultRangeBounds,
 	}
 }
 
 // isTraversable checks if a given waypoint is traversable corresponding to config.
-func (config *traverseConfig) isTraversable(waypoint Waypoint) bool {
+func (config *traverseConfig) isTraversable(node *traverseNode) bool {
+	waypoint := node.waypoint
 	if waypoint.Time().IsZero() && !config.includeNonCalendarNodes {
 		return false
 	}
 
+	for _, filter := range config.filters {
+		if !filter(node) {
+			return false
+		}
+	}
+
 	if config.nodesMode == TraverseAllNodes {
 		return true
 	}
@@ -120,13 +225,136 @@ func O_NON_CALENDAR() TraverseOption {
 	return func(o *traverseConfig) { o.includeNonCalendarNodes = true }
 }
 
-// Traverse traverses through a given waypoint (all its children recursively).
-func (v *Voyager) Traverse(cb func(w Waypoint), opts ...TraverseOption) error {
-	config := defaultTraverseConfig()
-	for _, opt := range opts {
-		opt(&config)
+// O_FILTER returns a TraverseOption for traversing only waypoints matching the given predicate.
+// Multiple filters can be combined: a waypoint must match all of them.
+//
+//nolint:stylecheck,staticcheck // ok
+func O_FILTER(predicate func(w Waypoint) bool) TraverseOption {
+	return func(o *traverseConfig) {
+		o.filters = append(o.filters, func(node *traverseNode) bool { return predicate(node.waypoint) })
+	}
+}
+
+// O_IDENTIFIER_GLOB returns a TraverseOption for traversing only waypoints whose identifier matches
+// the given glob pattern (see filepath.Match for the syntax).
+// Patterns without a path separator are matched against the last element of the identifier only,
+// so "*.log" matches "logs/2024/foobar.log". Malformed patterns match nothing.
+//
+//nolint:stylecheck,staticcheck // ok
+func O_IDENTIFIER_GLOB(pattern string) TraverseOption {
+	return O_FILTER(func(w Waypoint) bool {
+		name := w.Identifier()
+		if !strings.ContainsRune(pattern, filepath.Separator) {
+			name = filepath.Base(name)
+		}
+
+		matched, err := filepath.Match(pattern, name)
+		return err == nil && matched
+	})
+}
+
+// O_EXTENSIONS returns a TraverseOption for traversing only waypoints whose identifier
+// has one of given extensions (e.g. ".txt" or "txt"). Extensions are compared case-insensitively.
+//
+//nolint:stylecheck,staticcheck // ok
+func O_EXTENSIONS(extensions ...string) TraverseOption {
+	normalized := make([]string, len(extensions))
+	for i, ext := range extensions {
+		normalized[i] = "." + strings.ToLower(strings.TrimPrefix(ext, "."))
+	}
+
+	return O_FILTER(func(w Waypoint) bool {
+		return slices.Contains(normalized, strings.ToLower(filepath.Ext(w.Identifier())))
+	})
+}
+
+// O_MIN_DEPTH returns a TraverseOption for traversing only waypoints nested at least at the given depth.
+// The root waypoint has depth 0, its children have depth 1, etc.
+//
+//nolint:stylecheck,staticcheck // ok
+func O_MIN_DEPTH(depth int) TraverseOption {
+	return func(o *traverseConfig) {
+		o.filters = append(o.filters, func(node *traverseNode) bool { return node.depth >= depth })
+	}
+}
+
+// O_MAX_DEPTH returns a TraverseOption for traversing only waypoints nested at most at the given depth.
+// The root waypoint has depth 0, its children have depth 1, etc.
+//
+//nolint:stylecheck,staticcheck // ok
+func O_MAX_DEPTH(depth int) TraverseOption {
+	return func(o *traverseConfig) {
+		o.filters = append(o.filters, func(node *traverseNode) bool { return node.depth <= depth })
+	}
+}
+
+// O_TIE_BREAKER returns a TraverseOption for ordering waypoints having the same time.
+// The comparator is applied for Future direction and is reversed for Past direction,
+// so Past traversal is always the exact mirror of the Future one.
+//
+//nolint:stylecheck,staticcheck // ok
+func O_TIE_BREAKER(compare func(a, b Waypoint) int) TraverseOption {
+	return func(o *traverseConfig) { o.tieBreaker = compare }
+}
+
+// O_TIE_BREAK_BY_IDENTIFIER returns a TraverseOption for ordering waypoints having the same time
+// by their identifiers.
+//
+//nolint:stylecheck,staticcheck // ok
+func O_TIE_BREAK_BY_IDENTIFIER() TraverseOption {
+	return O_TIE_BREAKER(func(a, b Waypoint) int { return strings.Compare(a.Identifier(), b.Identifier()) })
+}
+
+// O_BOUNDS returns a TraverseOption for specifying inclusivity of range query bounds.
+//
+//nolint:stylecheck,staticcheck // ok
+func O_BOUNDS(bounds RangeBounds) TraverseOption {
+	return func(o *traverseConfig) { o.bounds = bounds }
+}
+
+// O_LIMIT returns a TraverseOption for limiting the number of traversed nodes.
+//
+//nolint:stylecheck,staticcheck // ok
+func O_LIMIT(limit int) TraverseOption {
+	return func(o *traverseConfig) { o.limit = limit }
+}
+
+// O_OFFSET returns a TraverseOption for skipping the first n traversable nodes.
+// Together with O_LIMIT it allows paging through traversal results.
+//
+//nolint:stylecheck,staticcheck // ok
+func O_OFFSET(offset int) TraverseOption {
+	return func(o *traverseConfig) { o.offset = offset }
+}
+
+// traversePager applies offset and limit to the sequence of traversable nodes.
+type traversePager struct {
+	offset, limit   int
+	skipped, passed int
+}
+
+func (config *traverseConfig) newPager() *traversePager {
+	return &traversePager{offset: config.offset, limit: config.limit}
+}
+
+// next is called for every traversable node and tells if the node should be passed to the callback.
+func (p *traversePager) next() bool {
+	if p.skipped < p.offset {
+		p.skipped++
+		return false
 	}
 
+	p.passed++
+	return true
+}
+
+// done tells if the limit is reached so the traversal can be stopped.
+func (p *traversePager) done() bool { return p.limit > 0 && p.passed >= p.limit }
+
+// compareFunc returns a comparator that sorts nodes chronologically in the configured direction.
+// Nodes having the same time are ordered via tieBreaker and then via the tree order,
+// so the result is always deterministic.
+func (config *traverseConfig) compareFunc() func(a, b *traverseNode) int {
 	// directionSign will be used in sorting func
 	var directionSign int
 	switch config.direction {
@@ -138,52 +366,239 @@ func (v *Voyager) Traverse(cb func(w Waypoint), opts ...TraverseOption) error {
 		panic("invalid traverse direction: " + config.direction)
 	}
 
-	sortFn := func(a, b Waypoint) int {
-		if a.Time().Equal(b.Time()) {
-			return directionSign
+	return func(a, b *traverseNode) int {
+		result := a.waypoint.Time().Compare(b.waypoint.Time())
+		if result == 0 && config.tieBreaker != nil {
+			result = config.tieBreaker(a.waypoint, b.waypoint)
+		}
+		if result == 0 {
+			result = cmp.Compare(a.index, b.index)
 		}
 
-		if a.Time().After(b.Time()) {
-			return directionSign
+		return result * directionSign
+	}
+}
+
+// parentWaypoint returns the waypoint of node's parent (nil for the root node).
+func (node *traverseNode) parentWaypoint() Waypoint {
+	if node.parent == nil {
+		return nil
+	}
+	return node.parent.waypoint
+}
+
+// hasAncestorIn checks if any of node's ancestors is in the given set.
+func (node *traverseNode) hasAncestorIn(set map[*traverseNode]bool) bool {
+	for ancestor := node.parent; ancestor != nil; ancestor = ancestor.parent {
+		if set[ancestor] {
+			return true
 		}
+	}
+	return false
+}
 
-		return -directionSign
+// collectTraverseNodes flattens the given waypoint and all its children (recursively)
+// into a list of nodes in the depth-first (parents first) tree order.
+// Collecting is stopped when ctx is done, as reading children may be slow (e.g. for network-mounted trees).
+func collectTraverseNodes(ctx context.Context, root Waypoint) ([]*traverseNode, error) {
+	nodes := make([]*traverseNode, 0)
+
+	var collect func(node *traverseNode) error
+	collect = func(node *traverseNode) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		node.index = len(nodes)
+		nodes = append(nodes, node)
+		if !node.waypoint.IsContainer() {
+			return nil
+		}
+		for _, child := range node.waypoint.Children() {
+			if err := collect(&traverseNode{waypoint: child, parent: node, depth: node.depth + 1}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := collect(&traverseNode{waypoint: root}); err != nil {
+		return nil, err
+	}
+
+	return nodes, nil
+}
+
+// newTraverseConfig builds a traverseConfig from the defaults and given options.
+func newTraverseConfig(opts ...TraverseOption) traverseConfig {
+	config := defaultTraverseConfig()
+	for _, opt := range opts {
+		opt(&config)
 	}
+	return config
+}
 
-	sorted := AllChildren(v.root)
-	sorted = append(sorted, v.root)
-	slices.SortFunc(sorted, sortFn)
+// SkipChildren is used as a return value from traversal callbacks to indicate that
+// children of the waypoint (recursively) are to be skipped.
+// In the chronological order only the children not visited yet are skipped
+// (e.g. with Past direction children of a container usually go before it).
+// It is not returned as an error by any function.
+//
+//nolint:errname,stylecheck,staticcheck // named in the spirit of filepath.SkipDir
+var SkipChildren = errors.New("skip children")
+
+// StopTraversal is used as a return value from traversal callbacks to indicate that
+// the traversal is to be stopped. It is not returned as an error by any function.
+//
+//nolint:errname,stylecheck,staticcheck // named in the spirit of filepath.SkipAll
+var StopTraversal = errors.New("stop traversal")
+
+// traverse passes traversable nodes in the chronological order to visit.
+// Traversal is stopped on ctx cancellation or on the first error returned by visit
+// (see SkipChildren and StopTraversal for special values).
+func (v *Voyager) traverse(ctx context.Context, config traverseConfig, visit func(node *traverseNode) error) error {
+	nodes, err := collectTraverseNodes(ctx, v.rootWaypoint())
+	if err != nil {
+		return err
+	}
+	slices.SortFunc(nodes, config.compareFunc())
 
-	for _, sw := range sorted {
-		if config.isTraversable(sw) {
-			cb(sw)
+	skipped := make(map[*traverseNode]bool)
+	pager := config.newPager()
+	for _, node := range nodes {
+		if pager.done() {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if len(skipped) > 0 && node.hasAncestorIn(skipped) {
+			continue
+		}
+		if !config.isTraversable(node) || !pager.next() {
+			continue
+		}
+
+		switch err := visit(node); {
+		case err == nil:
+		case errors.Is(err, SkipChildren):
+			skipped[node] = true
+		case errors.Is(err, StopTraversal):
+			return nil
+		default:
+			return err
 		}
 	}
 
 	return nil
 }
 
+// Traverse traverses through a given waypoint (all its children recursively).
+func (v *Voyager) Traverse(cb func(w Waypoint), opts ...TraverseOption) error {
+	return v.TraverseContext(context.Background(), func(w Waypoint) error {
+		cb(w)
+		return nil
+	}, opts...)
+}
+
+// TraverseContext traverses through a given waypoint (all its children recursively) as Traverse does,
+// but stops when ctx is done or when cb returns an error, returning that error.
+// The callback can return SkipChildren to skip the (not yet visited) children of the waypoint,
+// or StopTraversal to stop the traversal without an error.
+func (v *Voyager) TraverseContext(ctx context.Context, cb func(w Waypoint) error, opts ...TraverseOption) error {
+	return v.traverse(ctx, newTraverseConfig(opts...), func(node *traverseNode) error {
+		return cb(node.waypoint)
+	})
+}
+
+// All returns an iterator over traversable waypoints, in the same order as Traverse does.
+func (v *Voyager) All(opts ...TraverseOption) iter.Seq[Waypoint] {
+	config := newTraverseConfig(opts...)
+	return func(yield func(Waypoint) bool) {
+		_ = v.traverse(context.Background(), config, func(node *traverseNode) error {
+			if !yield(node.waypoint) {
+				return StopTraversal
+			}
+			return nil
+		})
+	}
+}
+
+// Between returns all waypoints whose time is in the range between from and to.
+// For waypoints knowing their time span (see SpanWaypoint) it's enough to overlap the range,
+// e.g. "2024/Mar" directory is found for the range of March 5-6.
+// By default both bounds are inclusive (use O_BOUNDS to change it).
+// Direction, nodes mode and paging (O_LIMIT, O_OFFSET) are configured via TraverseOptions as for Traverse.
+func (v *Voyager) Between(from, to time.Time, opts ...TraverseOption) ([]Waypoint, error) {
+	if from.After(to) {
+		return nil, fmt.Errorf("invalid range: from(%s) is after to(%s)", from, to)
+	}
+
+	config := newTraverseConfig(opts...)
+	inRange := func(o *traverseConfig) {
+		o.filters = append(o.filters, func(node *traverseNode) bool {
+			if span, ok := SpanOf(node.waypoint); ok {
+				return config.bounds.overlaps(from, to, span)
+			}
+			return config.bounds.contains(from, to, node.waypoint.Time())
+		})
+	}
+
+	found := make([]Waypoint, 0)
+	if err := v.Traverse(func(w Waypoint) {
+		found = append(found, w)
+	}, append(slices.Clip(opts), inRange)...); err != nil {
+		return nil, fmt.Errorf("could not traverse: %w", err)
+	}
+
+	return found, nil
+}
+
+// BetweenStrings is the same as Between, but from and to are given as strings
+// that are parsed via the voyager's parser, e.g. BetweenStrings("last-week", "today").
+func (v *Voyager) BetweenStrings(from, to string, opts ...TraverseOption) ([]Waypoint, error) {
+	fromTime, err := v.parser.Parse("", from)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse time(from): %w", err)
+	}
+	toTime, err := v.parser.Parse("", to)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse time(to): %w", err)
+	}
+
+	return v.Between(fromTime, toTime, opts...)
+}
+
 // Navigate returns the first found Waypoint that matches given time (as a string).
 // E.g. Navigate("yesterday") returns waypoint corresponding to the yesterday's date.
+//
+// Containers knowing their time span (see SpanWaypoint, e.g. "2024/Mar" directory of a TimeNamedWaypointFile
+// tree stands for the whole March) are not descended into if the span can't contain the target,
+// so with lazy file waypoints (see WithLazyChildren) only O(depth) directories are read.
 func (v *Voyager) Navigate(to string) (Waypoint, error) {
 	navigateTo, err := v.parser.Parse("", to)
 	if err != nil {
 		return nil, fmt.Errorf("could not parse time: %w", err)
 	}
 
+	// Navigating means finding the first match in the default (Past) traversal order:
+	// for waypoints having the same time it's the last one in the tree order.
 	var found Waypoint
-	if err := v.Traverse(func(w Waypoint) {
-		if found != nil {
-			return
-		}
-
+	var search func(w Waypoint)
+	search = func(w Waypoint) {
 		if w.Time().Equal(navigateTo) {
 			found = w
+		}
+		if !w.IsContainer() {
 			return
 		}
-	}); err != nil {
-		return nil, fmt.Errorf("could not traverse: %w", err)
+		for _, child := range w.Children() {
+			if span, ok := SpanOf(child); ok && !span.Contains(navigateTo) {
+				continue
+			}
+			search(child)
+		}
 	}
+	search(v.rootWaypoint())
 
 	return found, nil
 }
@@ -0,0 +1,117 @@
+package years
+
+import (
+	"container/heap"
+	"iter"
+)
+
+// MergeSource is a named Voyager to be merged with others (see MergedVoyager).
+type MergeSource struct {
+	Name    string
+	Voyager *Voyager
+}
+
+// SourceOf is a shortcut for creating a MergeSource from a waypoint root.
+func SourceOf(name string, root Waypoint, parserArg ...*Parser) MergeSource {
+	return MergeSource{Name: name, Voyager: NewVoyager(root, parserArg...)}
+}
+
+// SourcedWaypoint is a Waypoint emitted by a merged traversal, tagged with the name of its source.
+type SourcedWaypoint struct {
+	Waypoint
+
+	Source string
+}
+
+// MergedVoyager traverses several voyagers as a single chronologically ordered stream
+// (e.g. logs of several services kept in separate trees).
+// Every source is traversed on its own and the results are combined via streaming k-way merge,
+// so waypoints of all sources are never materialized and re-sorted together.
+type MergedVoyager struct {
+	sources []MergeSource
+}
+
+// NewMergedVoyager creates a MergedVoyager for given sources.
+// Waypoints of different sources having the same time are emitted in the order of sources.
+func NewMergedVoyager(sources ...MergeSource) *MergedVoyager {
+	return &MergedVoyager{sources: sources}
+}
+
+// mergeItem is the current head of a source's stream.
+type mergeItem struct {
+	node *traverseNode
+	// source is the index of the source (it breaks ties regardless of the direction)
+	source int
+	next   func() (Waypoint, bool)
+}
+
+// mergeHeap is a min-heap of sources' heads ordered by the traverse config's comparator,
+// heads of the same order are ordered by their sources.
+type mergeHeap struct {
+	items   []*mergeItem
+	compare func(a, b *traverseNode) int
+}
+
+func (h *mergeHeap) Len() int { return len(h.items) }
+func (h *mergeHeap) Less(i, j int) bool {
+	if result := h.compare(h.items[i].node, h.items[j].node); result != 0 {
+		return result < 0
+	}
+	return h.items[i].source < h.items[j].source
+}
+func (h *mergeHeap) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *mergeHeap) Push(x any)    { h.items = append(h.items, x.(*mergeItem)) }
+func (h *mergeHeap) Pop() any {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
+
+// All returns an iterator over traversable waypoints of all sources, merged chronologically.
+// Options are the same as for Voyager.Traverse, paging (O_OFFSET, O_LIMIT) is applied to the merged stream.
+func (mv *MergedVoyager) All(opts ...TraverseOption) iter.Seq[SourcedWaypoint] {
+	config := newTraverseConfig(opts...)
+
+	// paging is applied to the merged stream, so sources are traversed without it
+	sourceOpts := append(append([]TraverseOption{}, opts...), O_OFFSET(0), O_LIMIT(0))
+
+	return func(yield func(SourcedWaypoint) bool) {
+		h := &mergeHeap{compare: config.compareFunc()}
+		for i, source := range mv.sources {
+			next, stop := iter.Pull(source.Voyager.All(sourceOpts...))
+			defer stop()
+
+			if w, ok := next(); ok {
+				h.items = append(h.items, &mergeItem{node: &traverseNode{waypoint: w}, source: i, next: next})
+			}
+		}
+		heap.Init(h)
+
+		pager := config.newPager()
+		for h.Len() > 0 && !pager.done() {
+			head := h.items[0]
+			if pager.next() {
+				sw := SourcedWaypoint{Waypoint: head.node.waypoint, Source: mv.sources[head.source].Name}
+				if !yield(sw) {
+					return
+				}
+			}
+
+			if w, ok := head.next(); ok {
+				head.node = &traverseNode{waypoint: w}
+				heap.Fix(h, 0)
+			} else {
+				heap.Pop(h)
+			}
+		}
+	}
+}
+
+// Traverse traverses through waypoints of all sources merged chronologically.
+func (mv *MergedVoyager) Traverse(cb func(w SourcedWaypoint), opts ...TraverseOption) error {
+	for sw := range mv.All(opts...) {
+		cb(sw)
+	}
+
+	return nil
+}
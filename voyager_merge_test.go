@@ -0,0 +1,85 @@
+package years_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/amberpixels/years"
+	"github.com/expectto/be"
+)
+
+func TestMergedVoyager(t *testing.T) {
+	voyagerSetup(t)
+
+	logs, err := years.NewTimeNamedWaypointFile(filepath.Join(TestDataPath, "logs_via_timestamp"), "foobar_U@000.log")
+	be.Require(t, err).To(be.Succeed())
+
+	mv := years.NewMergedVoyager(
+		years.SourceOf("api", years.WaypointGroupFromStrings([]string{
+			"2024-05-24",
+			"2024-06-10",
+		}, "2006-01-02")),
+		years.SourceOf("logs", logs),
+		years.SourceOf("web", years.WaypointGroupFromStrings([]string{
+			"2024-05-20",
+			"2024-05-24",
+		}, "2006-01-02")),
+	)
+
+	collect := func(t *testing.T, opts ...years.TraverseOption) []string {
+		t.Helper()
+		visited := make([]string, 0)
+		err := mv.Traverse(func(w years.SourcedWaypoint) {
+			visited = append(visited, w.Source+": "+filepath.Base(w.Identifier()))
+		}, opts...)
+		be.Require(t, err).To(be.Succeed())
+		return visited
+	}
+
+	t.Run("Future", func(t *testing.T) {
+		be.Expect(t, collect(t, years.O_FUTURE(), years.O_LEAVES_ONLY())).To(be.Eq([]string{
+			"web: 2024-05-20",
+			"api: 2024-05-24",
+			"web: 2024-05-24",
+			"logs: foobar_1716559191.log",
+			"logs: foobar_1716559238.log",
+			"logs: foobar_1716559253.log",
+			"logs: foobar_1717669999.log",
+			"api: 2024-06-10",
+		}))
+	})
+
+	t.Run("Past with paging", func(t *testing.T) {
+		be.Expect(t, collect(t, years.O_PAST(), years.O_LEAVES_ONLY(), years.O_OFFSET(4), years.O_LIMIT(3))).To(be.Eq([]string{
+			"logs: foobar_1716559191.log",
+			"api: 2024-05-24",
+			"web: 2024-05-24",
+		}))
+	})
+
+	t.Run("ties in the order of sources", func(t *testing.T) {
+		tied := func(opts ...years.TraverseOption) []string {
+			visited := make([]string, 0)
+			for w := range mv.All(append(opts, years.O_LEAVES_ONLY())...) {
+				if filepath.Base(w.Identifier()) == "2024-05-24" {
+					visited = append(visited, w.Source)
+				}
+			}
+			return visited
+		}
+
+		be.Expect(t, tied(years.O_FUTURE())).To(be.Eq([]string{"api", "web"}))
+		be.Expect(t, tied(years.O_PAST())).To(be.Eq([]string{"api", "web"}))
+	})
+
+	t.Run("stop iterating early", func(t *testing.T) {
+		visited := 0
+		for range mv.All(years.O_FUTURE()) {
+			visited++
+			if visited == 2 {
+				break
+			}
+		}
+		be.Expect(t, visited).To(be.Eq(2))
+	})
+}
@@ -1,6 +1,8 @@
 package years_test
 
 import (
+	"context"
+	"errors"
 	"path/filepath"
 	"testing"
 	"time"
@@ -416,3 +418,324 @@ func TestVoyager_TimeNamedFile_LogsViaTimestamp(t *testing.T) {
 		}))
 	})
 }
+
+func TestVoyager_Between(t *testing.T) {
+	voyagerSetup(t, "2006-01-02 15:04")
+
+	v := years.NewVoyager(years.WaypointGroupFromStrings([]string{
+		"2024-03-04 09:00", // Monday
+		"2024-03-04 08:59",
+		"2024-03-05 12:00",
+		"2024-03-06 18:00", // Wednesday
+		"2024-03-06 18:01",
+		"2024-03-07 10:00",
+	}))
+
+	collectBetween := func(t *testing.T, from, to string, opts ...years.TraverseOption) []string {
+		t.Helper()
+		found, err := v.BetweenStrings(from, to, opts...)
+		be.Require(t, err).To(be.Succeed())
+		identifiers := make([]string, 0, len(found))
+		for _, w := range found {
+			identifiers = append(identifiers, w.Identifier())
+		}
+		return identifiers
+	}
+
+	t.Run("inclusive by default", func(t *testing.T) {
+		be.Expect(t, collectBetween(t, "2024-03-04 09:00", "2024-03-06 18:00", years.O_FUTURE())).To(be.Eq([]string{
+			"2024-03-04 09:00",
+			"2024-03-05 12:00",
+			"2024-03-06 18:00",
+		}))
+	})
+
+	t.Run("exclusive", func(t *testing.T) {
+		be.Expect(t, collectBetween(t, "2024-03-04 09:00", "2024-03-06 18:00",
+			years.O_FUTURE(), years.O_BOUNDS(years.RangeExclusive),
+		)).To(be.Eq([]string{
+			"2024-03-05 12:00",
+		}))
+	})
+
+	t.Run("half-open", func(t *testing.T) {
+		be.Expect(t, collectBetween(t, "2024-03-04 09:00", "2024-03-06 18:00",
+			years.O_PAST(), years.O_BOUNDS(years.RangeIncludeFrom),
+		)).To(be.Eq([]string{
+			"2024-03-05 12:00",
+			"2024-03-04 09:00",
+		}))
+	})
+
+	t.Run("paging", func(t *testing.T) {
+		be.Expect(t, collectBetween(t, "2024-03-04 00:00", "2024-03-07 23:59",
+			years.O_FUTURE(), years.O_OFFSET(1), years.O_LIMIT(2),
+		)).To(be.Eq([]string{
+			"2024-03-04 09:00",
+			"2024-03-05 12:00",
+		}))
+	})
+
+	t.Run("caller's options are kept", func(t *testing.T) {
+		opts := make([]years.TraverseOption, 1, 2)
+		opts[0] = years.O_FUTURE()
+		_, err := v.BetweenStrings("2024-03-04 09:00", "2024-03-06 18:00", opts...)
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, opts[:2][1] == nil).To(be.True())
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		_, err := v.BetweenStrings("2024-03-07 00:00", "2024-03-04 00:00")
+		be.Expect(t, err).To(be.HaveOccurred())
+	})
+
+	t.Run("unparseable bound", func(t *testing.T) {
+		_, err := v.BetweenStrings("not-a-time", "2024-03-04 00:00")
+		be.Expect(t, err).To(be.HaveOccurred())
+	})
+}
+
+func TestVoyager_BetweenSpans(t *testing.T) {
+	const testCalendarLayout = "2006/Jan/2006-01-02.txt"
+	voyagerSetup(t, "2006", "Jan", "2006-01-02")
+	calendarPath := filepath.Join(TestDataPath, "calendar1")
+
+	wf, err := years.NewTimeNamedWaypointFile(calendarPath, testCalendarLayout)
+	be.Require(t, err).To(be.Succeed())
+	v := years.NewVoyager(wf)
+
+	collectBetween := func(t *testing.T, v *years.Voyager, from, to time.Time, opts ...years.TraverseOption) []string {
+		t.Helper()
+		found, err := v.Between(from, to, append(opts, years.O_FUTURE())...)
+		be.Require(t, err).To(be.Succeed())
+		identifiers := make([]string, 0, len(found))
+		for _, w := range found {
+			identifiers = append(identifiers, w.Identifier())
+		}
+		return identifiers
+	}
+
+	t.Run("containers overlapping the range", func(t *testing.T) {
+		from := time.Date(2024, time.March, 5, 12, 0, 0, 0, time.UTC)
+		to := time.Date(2024, time.March, 5, 13, 0, 0, 0, time.UTC)
+		be.Expect(t, collectBetween(t, v, from, to)).To(be.Eq([]string{
+			"internal/testdata/calendar1/2024",
+			"internal/testdata/calendar1/2024/Mar",
+			"internal/testdata/calendar1/2024/Mar/2024-03-05.txt",
+		}))
+	})
+
+	t.Run("span ends are exclusive", func(t *testing.T) {
+		from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC)
+		be.Expect(t, collectBetween(t, v, from, to, years.O_CONTAINERS_ONLY())).To(be.Eq([]string{
+			"internal/testdata/calendar1/2024",
+			"internal/testdata/calendar1/2024/Mar",
+		}))
+		be.Expect(t, collectBetween(t, v, from, to, years.O_LEAVES_ONLY(), years.O_BOUNDS(years.RangeIncludeFrom))).
+			To(be.Eq([]string{}))
+		be.Expect(t, collectBetween(t, v, from, to, years.O_LEAVES_ONLY())).To(be.Eq([]string{
+			"internal/testdata/calendar1/2024/Mar/2024-03-05.txt",
+		}))
+	})
+
+	t.Run("strings at day granularity", func(t *testing.T) {
+		days := years.NewVoyager(years.WaypointGroupFromStrings([]string{"2024-03-04", "2024-03-05"}, "2006-01-02"))
+		from := time.Date(2024, time.March, 4, 18, 0, 0, 0, time.UTC)
+		to := time.Date(2024, time.March, 4, 20, 0, 0, 0, time.UTC)
+		be.Expect(t, collectBetween(t, days, from, to)).To(be.Eq([]string{"2024-03-04"}))
+	})
+}
+
+func TestWaypoint_Spans(t *testing.T) {
+	voyagerSetup(t)
+
+	day := years.NewWaypointString("2024-03-05", "2006-01-02")
+	span, ok := years.SpanOf(day)
+	be.Require(t, ok).To(be.True())
+	be.Expect(t, span.End).To(be.Eq(time.Date(2024, time.March, 6, 0, 0, 0, 0, time.UTC)))
+
+	month := years.NewWaypointString("2024-03", "2006-01")
+	be.Expect(t, month.End()).To(be.Eq(time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)))
+
+	// values of layouts having clock parts are instants
+	instant := years.NewWaypointString("2024-03-05 10:00", "2006-01-02 15:04")
+	be.Expect(t, instant.End()).To(be.Eq(instant.Start()))
+	_, ok = years.SpanOf(instant)
+	be.Expect(t, ok).To(be.False())
+
+	group := years.NewWaypointGroup("group", month, instant, years.NewWaypointString("not-a-time"))
+	span, ok = years.SpanOf(group)
+	be.Require(t, ok).To(be.True())
+	be.Expect(t, span).To(be.Eq(years.Period{
+		Start: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+	}))
+
+	// spans of containers not knowing their span are unknown
+	_, ok = years.SpanOf(years.NewWaypointGroup("outer", group, years.NewWaypointGroup("empty")))
+	be.Expect(t, ok).To(be.False())
+}
+
+func TestVoyager_TraverseFilters(t *testing.T) {
+	const testCalendarLayout = "2006/Jan/2006-01-02.txt"
+	voyagerSetup(t, "2006", "Jan", "2006-01-02")
+	calendarPath := filepath.Join(TestDataPath, "calendar1")
+
+	wf, err := years.NewTimeNamedWaypointFile(calendarPath, testCalendarLayout)
+	be.Require(t, err).To(be.Succeed())
+	v := years.NewVoyager(wf)
+
+	t.Run("by identifier glob", func(t *testing.T) {
+		identifiers := collectTraverse(t, v, years.O_FUTURE(), years.O_IDENTIFIER_GLOB("2024-03-*"))
+		be.Expect(t, identifiers).To(be.Eq([]string{
+			"internal/testdata/calendar1/2024/Mar/2024-03-05.txt",
+			"internal/testdata/calendar1/2024/Mar/2024-03-06.txt",
+		}))
+	})
+
+	t.Run("by extension", func(t *testing.T) {
+		identifiers := collectTraverse(t, v, years.O_PAST(), years.O_EXTENSIONS("TXT"))
+		be.Expect(t, identifiers).To(be.Eq([]string{
+			"internal/testdata/calendar1/2024/Mar/2024-03-06.txt",
+			"internal/testdata/calendar1/2024/Mar/2024-03-05.txt",
+			"internal/testdata/calendar1/2024/Feb/2024-02-01.txt",
+		}))
+	})
+
+	t.Run("by depth", func(t *testing.T) {
+		identifiers := collectTraverse(t, v, years.O_FUTURE(), years.O_MIN_DEPTH(2), years.O_MAX_DEPTH(2))
+		be.Expect(t, identifiers).To(be.Eq([]string{
+			"internal/testdata/calendar1/2024/Jan",
+			"internal/testdata/calendar1/2024/Feb",
+			"internal/testdata/calendar1/2024/Mar",
+		}))
+	})
+
+	t.Run("by custom predicate", func(t *testing.T) {
+		identifiers := collectTraverse(t, v, years.O_FUTURE(), years.O_FILTER(func(w years.Waypoint) bool {
+			return w.Time().Month() == time.February
+		}))
+		be.Expect(t, identifiers).To(be.Eq([]string{
+			"internal/testdata/calendar1/2024/Feb",
+			"internal/testdata/calendar1/2024/Feb/2024-02-01.txt",
+		}))
+	})
+}
+
+func TestVoyager_TraverseTieBreaking(t *testing.T) {
+	voyagerSetup(t)
+
+	// identifiers are equal for the same time, so the order is checked via pointers
+	group := years.NewVoyager(
+		years.WaypointGroupFromStrings([]string{
+			"2024-03-05",
+			"2024-03-06",
+			"2024-03-05",
+			"2024-03-04",
+		}, "2006-01-02"),
+	)
+
+	var visited []years.Waypoint
+	err := group.Traverse(func(w years.Waypoint) { visited = append(visited, w) }, years.O_FUTURE())
+	be.Require(t, err).To(be.Succeed())
+	be.Require(t, visited).To(be.HaveLength(4))
+
+	var visitedPast []years.Waypoint
+	err = group.Traverse(func(w years.Waypoint) { visitedPast = append(visitedPast, w) }, years.O_PAST())
+	be.Require(t, err).To(be.Succeed())
+	be.Require(t, visitedPast).To(be.HaveLength(4))
+
+	// Past is the exact mirror of Future
+	for i := range visited {
+		be.Expect(t, visitedPast[len(visitedPast)-1-i] == visited[i]).To(be.True())
+	}
+
+	t.Run("custom tie breaker", func(t *testing.T) {
+		v := years.NewVoyager(years.NewWaypointGroup("",
+			years.NewWaypointString("2024-03-05", "2006-01-02"),
+			years.NewWaypointString("2024-03-05T00:00:00Z", time.RFC3339),
+			years.NewWaypointString("2024-03-04", "2006-01-02"),
+		))
+
+		identifiers := collectTraverse(t, v, years.O_FUTURE(), years.O_TIE_BREAK_BY_IDENTIFIER())
+		be.Expect(t, identifiers).To(be.Eq([]string{
+			"2024-03-04",
+			"2024-03-05",
+			"2024-03-05T00:00:00Z",
+		}))
+
+		identifiers = collectTraverse(t, v, years.O_FUTURE(), years.O_TIE_BREAKER(func(a, b years.Waypoint) int {
+			return len(b.Identifier()) - len(a.Identifier())
+		}))
+		be.Expect(t, identifiers).To(be.Eq([]string{
+			"2024-03-04",
+			"2024-03-05T00:00:00Z",
+			"2024-03-05",
+		}))
+	})
+}
+
+func TestVoyager_TraverseContext(t *testing.T) {
+	const testCalendarLayout = "2006/Jan/2006-01-02.txt"
+	voyagerSetup(t, "2006", "Jan", "2006-01-02")
+	calendarPath := filepath.Join(TestDataPath, "calendar1")
+
+	wf, err := years.NewTimeNamedWaypointFile(calendarPath, testCalendarLayout)
+	be.Require(t, err).To(be.Succeed())
+	v := years.NewVoyager(wf)
+
+	t.Run("skip children", func(t *testing.T) {
+		identifiers := make([]string, 0)
+		err := v.TraverseContext(context.Background(), func(w years.Waypoint) error {
+			identifiers = append(identifiers, w.Identifier())
+			if filepath.Base(w.Identifier()) == "Feb" {
+				return years.SkipChildren
+			}
+			return nil
+		}, years.O_FUTURE())
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, identifiers).To(be.Eq([]string{
+			"internal/testdata/calendar1/2024",
+			"internal/testdata/calendar1/2024/Jan",
+			"internal/testdata/calendar1/2024/Feb",
+			"internal/testdata/calendar1/2024/Mar",
+			"internal/testdata/calendar1/2024/Mar/2024-03-05.txt",
+			"internal/testdata/calendar1/2024/Mar/2024-03-06.txt",
+		}))
+	})
+
+	t.Run("stop traversal", func(t *testing.T) {
+		identifiers := make([]string, 0)
+		err := v.TraverseContext(context.Background(), func(w years.Waypoint) error {
+			identifiers = append(identifiers, w.Identifier())
+			return years.StopTraversal
+		}, years.O_PAST())
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, identifiers).To(be.Eq([]string{
+			"internal/testdata/calendar1/2024/Mar/2024-03-06.txt",
+		}))
+	})
+
+	t.Run("callback error", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		calls := 0
+		err := v.TraverseContext(context.Background(), func(years.Waypoint) error {
+			calls++
+			return errBoom
+		})
+		be.Expect(t, errors.Is(err, errBoom)).To(be.True())
+		be.Expect(t, calls).To(be.Eq(1))
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := v.TraverseContext(ctx, func(years.Waypoint) error { return nil })
+		be.Expect(t, errors.Is(err, context.Canceled)).To(be.True())
+
+		err = v.TraverseTreeContext(ctx, func(years.TreeNode) error { return nil })
+		be.Expect(t, errors.Is(err, context.Canceled)).To(be.True())
+	})
+}
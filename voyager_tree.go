@@ -0,0 +1,111 @@
+package years
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+// TreeNode is a waypoint visited by a tree-order traversal together with its position in the tree.
+type TreeNode struct {
+	Waypoint Waypoint
+
+	// Parent is the container the waypoint belongs to (nil for the root waypoint)
+	Parent Waypoint
+
+	// Depth is the nesting level of the waypoint: 0 for the root, 1 for its children, etc
+	Depth int
+}
+
+// O_ON_ENTER returns a TraverseOption for setting a hook that is called before descending into a container.
+// Used only by tree-order traversal (see Voyager.TraverseTree).
+//
+//nolint:stylecheck,staticcheck // ok
+func O_ON_ENTER(hook func(node TreeNode)) TraverseOption {
+	return func(o *traverseConfig) { o.onEnter = hook }
+}
+
+// O_ON_LEAVE returns a TraverseOption for setting a hook that is called after all children of a container
+// are traversed. Used only by tree-order traversal (see Voyager.TraverseTree).
+//
+//nolint:stylecheck,staticcheck // ok
+func O_ON_LEAVE(hook func(node TreeNode)) TraverseOption {
+	return func(o *traverseConfig) { o.onLeave = hook }
+}
+
+// TraverseTree traverses through a given waypoint in the tree order (depth-first),
+// keeping the year -> month -> day nesting: a container is visited before its children,
+// and children of every container are sorted chronologically in the configured direction.
+//
+// The callback is called only for traversable nodes (respecting nodes mode, filters, etc),
+// while O_ON_ENTER and O_ON_LEAVE hooks are called for every container being descended into
+// (every entered container is left, even when the traversal is stopped).
+func (v *Voyager) TraverseTree(cb func(node TreeNode), opts ...TraverseOption) error {
+	return v.TraverseTreeContext(context.Background(), func(node TreeNode) error {
+		cb(node)
+		return nil
+	}, opts...)
+}
+
+// TraverseTreeContext traverses through a given waypoint in the tree order as TraverseTree does,
+// but stops when ctx is done or when cb returns an error, returning that error.
+// The callback can return SkipChildren to not descend into the container,
+// or StopTraversal to stop the traversal without an error.
+func (v *Voyager) TraverseTreeContext(ctx context.Context, cb func(node TreeNode) error, opts ...TraverseOption) error {
+	config := newTraverseConfig(opts...)
+	compare := config.compareFunc()
+	pager := config.newPager()
+
+	var walk func(node *traverseNode) error
+	walk = func(node *traverseNode) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		treeNode := TreeNode{Waypoint: node.waypoint, Parent: node.parentWaypoint(), Depth: node.depth}
+		if config.isTraversable(node) && pager.next() {
+			switch err := cb(treeNode); {
+			case err == nil:
+			case errors.Is(err, SkipChildren):
+				return nil
+			default:
+				return err
+			}
+		}
+		if pager.done() {
+			return StopTraversal
+		}
+		if !node.waypoint.IsContainer() {
+			return nil
+		}
+
+		if config.onEnter != nil {
+			config.onEnter(treeNode)
+		}
+		if config.onLeave != nil {
+			// entered containers are left even if the traversal stops within them
+			defer config.onLeave(treeNode)
+		}
+
+		children := node.waypoint.Children()
+		childNodes := make([]*traverseNode, len(children))
+		for i, child := range children {
+			childNodes[i] = &traverseNode{waypoint: child, parent: node, depth: node.depth + 1, index: i}
+		}
+		slices.SortFunc(childNodes, compare)
+
+		for _, childNode := range childNodes {
+			if err := walk(childNode); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err := walk(&traverseNode{waypoint: v.rootWaypoint()}); err != nil && !errors.Is(err, StopTraversal) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package years_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/amberpixels/years"
+	"github.com/expectto/be"
+)
+
+func TestVoyager_TraverseTree(t *testing.T) {
+	const testCalendarLayout = "2006/Jan/2006-01-02.txt"
+	voyagerSetup(t, "2006", "Jan", "2006-01-02")
+	calendarPath := filepath.Join(TestDataPath, "calendar1")
+
+	wf, err := years.NewTimeNamedWaypointFile(calendarPath, testCalendarLayout)
+	be.Require(t, err).To(be.Succeed())
+	v := years.NewVoyager(wf)
+
+	// render draws the tree as a sidebar: indented base names, with markers for entering/leaving containers
+	render := func(t *testing.T, opts ...years.TraverseOption) []string {
+		t.Helper()
+		lines := make([]string, 0)
+		opts = append(opts,
+			years.O_ON_ENTER(func(node years.TreeNode) {
+				lines = append(lines, strings.Repeat("  ", node.Depth)+"> "+filepath.Base(node.Waypoint.Identifier()))
+			}),
+			years.O_ON_LEAVE(func(node years.TreeNode) {
+				lines = append(lines, strings.Repeat("  ", node.Depth)+"< "+filepath.Base(node.Waypoint.Identifier()))
+			}),
+		)
+		err := v.TraverseTree(func(node years.TreeNode) {
+			line := strings.Repeat("  ", node.Depth) + filepath.Base(node.Waypoint.Identifier())
+			if node.Parent != nil {
+				line += " @" + filepath.Base(node.Parent.Identifier())
+			}
+			lines = append(lines, line)
+		}, opts...)
+		be.Require(t, err).To(be.Succeed())
+		return lines
+	}
+
+	t.Run("Future / All nodes", func(t *testing.T) {
+		be.Expect(t, render(t, years.O_FUTURE())).To(be.Eq([]string{
+			"> calendar1",
+			"  2024 @calendar1",
+			"  > 2024",
+			"    Jan @2024",
+			"    > Jan",
+			"    < Jan",
+			"    Feb @2024",
+			"    > Feb",
+			"      2024-02-01.txt @Feb",
+			"    < Feb",
+			"    Mar @2024",
+			"    > Mar",
+			"      2024-03-05.txt @Mar",
+			"      2024-03-06.txt @Mar",
+			"    < Mar",
+			"  < 2024",
+			"< calendar1",
+		}))
+	})
+
+	t.Run("Past / Leaves only", func(t *testing.T) {
+		be.Expect(t, render(t, years.O_PAST(), years.O_LEAVES_ONLY())).To(be.Eq([]string{
+			"> calendar1",
+			"  > 2024",
+			"    > Mar",
+			"      2024-03-06.txt @Mar",
+			"      2024-03-05.txt @Mar",
+			"    < Mar",
+			"    > Feb",
+			"      2024-02-01.txt @Feb",
+			"    < Feb",
+			"    > Jan",
+			"    < Jan",
+			"  < 2024",
+			"< calendar1",
+		}))
+	})
+
+	t.Run("limit stops the traversal", func(t *testing.T) {
+		identifiers := make([]string, 0)
+		err := v.TraverseTree(func(node years.TreeNode) {
+			identifiers = append(identifiers, node.Waypoint.Identifier())
+		}, years.O_FUTURE(), years.O_LEAVES_ONLY(), years.O_LIMIT(2))
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, identifiers).To(be.Eq([]string{
+			"internal/testdata/calendar1/2024/Feb/2024-02-01.txt",
+			"internal/testdata/calendar1/2024/Mar/2024-03-05.txt",
+		}))
+	})
+
+	t.Run("limit leaves entered containers", func(t *testing.T) {
+		be.Expect(t, render(t, years.O_FUTURE(), years.O_LEAVES_ONLY(), years.O_LIMIT(2))).To(be.Eq([]string{
+			"> calendar1",
+			"  > 2024",
+			"    > Jan",
+			"    < Jan",
+			"    > Feb",
+			"      2024-02-01.txt @Feb",
+			"    < Feb",
+			"    > Mar",
+			"      2024-03-05.txt @Mar",
+			"    < Mar",
+			"  < 2024",
+			"< calendar1",
+		}))
+	})
+
+	t.Run("stop leaves entered containers", func(t *testing.T) {
+		entered, left := 0, 0
+		err := v.TraverseTreeContext(context.Background(), func(node years.TreeNode) error {
+			if filepath.Base(node.Waypoint.Identifier()) == "2024-02-01.txt" {
+				return years.StopTraversal
+			}
+			return nil
+		}, years.O_FUTURE(),
+			years.O_ON_ENTER(func(years.TreeNode) { entered++ }),
+			years.O_ON_LEAVE(func(years.TreeNode) { left++ }),
+		)
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, entered).To(be.Eq(4))
+		be.Expect(t, left).To(be.Eq(entered))
+	})
+
+	t.Run("skip children and stop", func(t *testing.T) {
+		identifiers := make([]string, 0)
+		err := v.TraverseTreeContext(context.Background(), func(node years.TreeNode) error {
+			identifiers = append(identifiers, filepath.Base(node.Waypoint.Identifier()))
+			switch filepath.Base(node.Waypoint.Identifier()) {
+			case "Feb":
+				return years.SkipChildren
+			case "2024-03-05.txt":
+				return years.StopTraversal
+			}
+			return nil
+		}, years.O_FUTURE())
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, identifiers).To(be.Eq([]string{"2024", "Jan", "Feb", "Mar", "2024-03-05.txt"}))
+	})
+}
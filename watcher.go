@@ -0,0 +1,325 @@
+package years
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WatchEventType is a type of changes reported by Watcher.
+type WatchEventType string
+
+const (
+	// WatchCreated means a new waypoint appeared (renamed files appear as removed + created ones).
+	WatchCreated WatchEventType = "created"
+	// WatchRemoved means a waypoint disappeared.
+	WatchRemoved WatchEventType = "removed"
+	// WatchModified means the waypoint's time or its file's modification time changed.
+	WatchModified WatchEventType = "modified"
+)
+
+// WatchEvent is a change of the watched waypoint tree.
+type WatchEvent struct {
+	Type WatchEventType
+	// Waypoint is the affected waypoint: the new one for created/modified, the old one for removed.
+	Waypoint Waypoint
+	// Time is the time of the waypoint.
+	Time time.Time
+}
+
+// DefaultWatchInterval is the default debounce interval for notifications and the polling interval.
+const DefaultWatchInterval = time.Second
+
+// watchConfig holds configuration for Watcher.
+type watchConfig struct {
+	interval    time.Duration
+	polling     bool
+	fileOptions []FileOption
+	logger      *slog.Logger
+}
+
+// WatchOption defines functional options for Watcher.
+type WatchOption func(*watchConfig)
+
+// WithWatchInterval opts to use the given interval (DefaultWatchInterval by default):
+// with notifications (inotify) it's the quiet period before the tree is rebuilt,
+// with polling it's the period of rebuilding.
+func WithWatchInterval(interval time.Duration) WatchOption {
+	return func(c *watchConfig) { c.interval = interval }
+}
+
+// WithWatchPolling opts to poll for changes (by rebuilding the tree periodically) instead of
+// using notifications. Polling is used anyway if notifications are not available (e.g. on non-Linux systems).
+func WithWatchPolling() WatchOption {
+	return func(c *watchConfig) { c.polling = true }
+}
+
+// WithWatchFileOptions opts to build file waypoints with the given options (see NewTimeNamedWatcher).
+func WithWatchFileOptions(opts ...FileOption) WatchOption {
+	return func(c *watchConfig) { c.fileOptions = opts }
+}
+
+// WithWatchLogger opts to log errors (e.g. failed rebuilds) to the given logger instead of slog.Default.
+func WithWatchLogger(logger *slog.Logger) WatchOption {
+	return func(c *watchConfig) { c.logger = logger }
+}
+
+// Watcher keeps a waypoint tree of a directory up to date: on changes in the directory (or its subdirectories)
+// the tree is rebuilt and compared to the previous one, emitting change events (see Events).
+// Changes are reported via inotify on Linux, otherwise (or if inotify fails) the directory is polled.
+type Watcher struct {
+	dir    string
+	build  func() (Waypoint, error)
+	config watchConfig
+
+	current   Waypoint
+	snapshot  map[string]watchedWaypoint
+	currentMu sync.RWMutex
+
+	events   chan WatchEvent
+	triggers chan struct{}
+	notifier watchNotifier
+
+	done      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+}
+
+// watchedWaypoint is a waypoint of a tree snapshot along with its file's modification time (if it's a file).
+type watchedWaypoint struct {
+	waypoint Waypoint
+	t        time.Time
+	modTime  time.Time
+}
+
+// NewWatcher watches the given directory, (re)building its waypoint tree via build.
+// The tree is built once before the watcher returns.
+//
+// Every change (after the quiet interval) rebuilds the whole tree and compares it to the previous one,
+// so a refresh costs O(tree) reads: for big trees prefer lazy waypoints (see WithLazyChildren)
+// and a longer interval (see WithWatchInterval).
+func NewWatcher(dir string, build func() (Waypoint, error), opts ...WatchOption) (*Watcher, error) {
+	config := watchConfig{interval: DefaultWatchInterval}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	w := &Watcher{
+		dir:      dir,
+		build:    build,
+		config:   config,
+		events:   make(chan WatchEvent, 64),
+		triggers: make(chan struct{}, 1),
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+
+	// watching starts before building, so changes made while building are not missed
+	if !config.polling {
+		notifier, err := newWatchNotifier(w.triggers, w.log)
+		if err == nil {
+			w.notifier = notifier
+			err = notifier.watch(dir)
+		}
+		if err != nil {
+			w.log(fmt.Sprintf("watcher: notifications are not available, polling instead: %s", err))
+			if w.notifier != nil {
+				_ = w.notifier.close()
+				w.notifier = nil
+			}
+		}
+	}
+
+	root, err := build()
+	if err != nil {
+		if w.notifier != nil {
+			_ = w.notifier.close()
+		}
+		return nil, fmt.Errorf("could not build waypoints: %w", err)
+	}
+	w.current, w.snapshot = root, snapshotOf(root)
+
+	go w.run()
+
+	return w, nil
+}
+
+// NewTimeNamedWatcher watches the tree of time named files (see NewTimeNamedWaypointFileWithOptions).
+// File options are given via WithWatchFileOptions.
+func NewTimeNamedWatcher(path string, fullLayout string, opts ...WatchOption) (*Watcher, error) {
+	config := watchConfig{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return NewWatcher(path, func() (Waypoint, error) {
+		return NewTimeNamedWaypointFileWithOptions(path, fullLayout, config.fileOptions...)
+	}, opts...)
+}
+
+// Events returns the channel of change events. It's closed when the watcher is closed.
+// Events are to be consumed: the watcher waits for the consumer when the channel's buffer is full.
+func (w *Watcher) Events() <-chan WatchEvent { return w.events }
+
+// Waypoint returns the current tree.
+func (w *Watcher) Waypoint() Waypoint {
+	w.currentMu.RLock()
+	defer w.currentMu.RUnlock()
+	return w.current
+}
+
+// Voyager returns a voyager over the watched tree: every traversal (or navigation) takes the current tree.
+func (w *Watcher) Voyager(parserArg ...*Parser) *Voyager {
+	return NewVoyager(&watchedRoot{watcher: w}, parserArg...)
+}
+
+// Close stops watching and closes the events channel.
+func (w *Watcher) Close() error {
+	var err error
+	w.closeOnce.Do(func() {
+		close(w.done)
+		if w.notifier != nil {
+			err = w.notifier.close()
+		}
+		<-w.stopped
+		close(w.events)
+	})
+	return err
+}
+
+// run rebuilds the tree on notifications (after a quiet interval) or periodically when polling.
+func (w *Watcher) run() {
+	defer close(w.stopped)
+
+	var tick <-chan time.Time
+	if w.notifier == nil {
+		ticker := time.NewTicker(w.config.interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	debounce := time.NewTimer(w.config.interval)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-w.triggers:
+			debounce.Reset(w.config.interval)
+		case <-debounce.C:
+			w.refresh()
+		case <-tick:
+			w.refresh()
+		}
+	}
+}
+
+// refresh rebuilds the tree and emits events for the differences.
+func (w *Watcher) refresh() {
+	root, err := w.build()
+	if err != nil {
+		w.log(fmt.Sprintf("watcher: could not rebuild waypoints of %s: %s", w.dir, err))
+		return
+	}
+
+	snapshot := snapshotOf(root)
+	w.currentMu.Lock()
+	previous := w.snapshot
+	w.current, w.snapshot = root, snapshot
+	w.currentMu.Unlock()
+
+	for _, event := range diffSnapshots(previous, snapshot) {
+		select {
+		case w.events <- event:
+		case <-w.done:
+			return
+		}
+	}
+}
+
+func (w *Watcher) log(msg string) {
+	logger := w.config.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger.Info(msg)
+}
+
+// snapshotOf collects all waypoints of the tree (except the root) by their identifiers.
+func snapshotOf(root Waypoint) map[string]watchedWaypoint {
+	snapshot := make(map[string]watchedWaypoint)
+
+	var collect func(w Waypoint)
+	collect = func(w Waypoint) {
+		for _, child := range w.Children() {
+			watched := watchedWaypoint{waypoint: child, t: child.Time()}
+			switch v := child.(type) {
+			case *WaypointFile:
+				watched.modTime = v.fileInfo.ModTime()
+			case *TimeNamedWaypointFile:
+				watched.modTime = v.fileInfo.ModTime()
+			}
+			snapshot[child.Identifier()] = watched
+
+			if child.IsContainer() {
+				collect(child)
+			}
+		}
+	}
+	collect(root)
+
+	return snapshot
+}
+
+// diffSnapshots returns events turning the previous snapshot into the current one:
+// removed waypoints go first, then created and modified ones (ordered by identifiers).
+func diffSnapshots(previous, current map[string]watchedWaypoint) []WatchEvent {
+	removed := make([]WatchEvent, 0)
+	changed := make([]WatchEvent, 0)
+
+	for identifier, old := range previous {
+		if _, ok := current[identifier]; !ok {
+			removed = append(removed, WatchEvent{Type: WatchRemoved, Waypoint: old.waypoint, Time: old.t})
+		}
+	}
+	for identifier, now := range current {
+		old, ok := previous[identifier]
+		switch {
+		case !ok:
+			changed = append(changed, WatchEvent{Type: WatchCreated, Waypoint: now.waypoint, Time: now.t})
+		case !old.t.Equal(now.t) || !old.modTime.Equal(now.modTime):
+			changed = append(changed, WatchEvent{Type: WatchModified, Waypoint: now.waypoint, Time: now.t})
+		}
+	}
+
+	byIdentifier := func(a, b WatchEvent) int {
+		return strings.Compare(a.Waypoint.Identifier(), b.Waypoint.Identifier())
+	}
+	slices.SortFunc(removed, byIdentifier)
+	slices.SortFunc(changed, byIdentifier)
+
+	return append(removed, changed...)
+}
+
+// watchedRoot is the root waypoint of Watcher.Voyager: it's a live waypoint (Voyager traverses the current tree),
+// other methods delegate to the current tree as well.
+type watchedRoot struct{ watcher *Watcher }
+
+func (r *watchedRoot) current() Waypoint    { return r.watcher.Waypoint() }
+func (r *watchedRoot) Time() time.Time      { return r.current().Time() }
+func (r *watchedRoot) Identifier() string   { return r.current().Identifier() }
+func (r *watchedRoot) IsContainer() bool    { return r.current().IsContainer() }
+func (r *watchedRoot) Children() []Waypoint { return r.current().Children() }
+
+// watchNotifier notifies about changes in watched directories (see newWatchNotifier).
+type watchNotifier interface {
+	// watch watches the directory along with all its subdirectories.
+	// Subdirectories created later are watched by the notifier itself as they appear.
+	watch(dir string) error
+	close() error
+}
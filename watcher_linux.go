@@ -0,0 +1,121 @@
+//go:build linux
+
+package years
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+)
+
+// inotifyMask is the set of inotify events meaning changes of a watched directory.
+const inotifyMask = syscall.IN_CREATE | syscall.IN_DELETE | syscall.IN_MODIFY | syscall.IN_ATTRIB |
+	syscall.IN_CLOSE_WRITE | syscall.IN_MOVED_FROM | syscall.IN_MOVED_TO |
+	syscall.IN_DELETE_SELF | syscall.IN_MOVE_SELF
+
+// inotifyNotifier is the watchNotifier via inotify. Any batch of events means the tree is to be rebuilt,
+// events are parsed only to watch directories appearing in watched ones.
+type inotifyNotifier struct {
+	fd   int
+	file *os.File
+	log  func(msg string)
+
+	// dirs are paths of watched directories by their watch descriptors
+	dirs   map[int32]string
+	dirsMu sync.Mutex
+}
+
+func newWatchNotifier(triggers chan<- struct{}, log func(msg string)) (watchNotifier, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, fmt.Errorf("could not init inotify: %w", err)
+	}
+
+	// non-blocking fd makes the file use the runtime poller, so Close interrupts a pending Read
+	n := &inotifyNotifier{fd: fd, file: os.NewFile(uintptr(fd), "inotify"), log: log, dirs: make(map[int32]string)}
+	go n.read(triggers)
+
+	return n, nil
+}
+
+// read reads events till the notifier is closed, triggering a rebuild for each batch.
+func (n *inotifyNotifier) read(triggers chan<- struct{}) {
+	buf := make([]byte, 64*(syscall.SizeofInotifyEvent+syscall.NAME_MAX+1))
+	for {
+		size, err := n.file.Read(buf)
+		if err != nil {
+			return
+		}
+		n.handle(buf[:size])
+
+		// a pending trigger is enough
+		select {
+		case triggers <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// handle watches directories created in (or moved into) watched ones and forgets removed watches.
+// New directories are watched before the rebuild is triggered, so their further changes are not missed.
+func (n *inotifyNotifier) handle(events []byte) {
+	for len(events) >= syscall.SizeofInotifyEvent {
+		wd := int32(binary.NativeEndian.Uint32(events[0:]))
+		mask := binary.NativeEndian.Uint32(events[4:])
+		nameLen := int(binary.NativeEndian.Uint32(events[12:]))
+		if len(events) < syscall.SizeofInotifyEvent+nameLen {
+			return
+		}
+		name := string(bytes.TrimRight(events[syscall.SizeofInotifyEvent:syscall.SizeofInotifyEvent+nameLen], "\x00"))
+		events = events[syscall.SizeofInotifyEvent+nameLen:]
+
+		n.dirsMu.Lock()
+		dir, ok := n.dirs[wd]
+		if mask&syscall.IN_IGNORED != 0 {
+			delete(n.dirs, wd)
+		}
+		n.dirsMu.Unlock()
+
+		if ok && name != "" && mask&syscall.IN_ISDIR != 0 && mask&(syscall.IN_CREATE|syscall.IN_MOVED_TO) != 0 {
+			if err := n.watch(filepath.Join(dir, name)); err != nil {
+				n.log(fmt.Sprintf("watcher: %s", err))
+			}
+		}
+	}
+}
+
+// watch adds watches for the directory and all its subdirectories. Watching already watched ones
+// just keeps their watches (updating their paths, e.g. for moved directories).
+func (n *inotifyNotifier) watch(dir string) error {
+	var errs []error
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			errs = append(errs, err)
+			return nil
+		}
+		if !d.IsDir() {
+			return nil
+		}
+
+		// the lock is held while adding, so events of the new watch are resolved to its path
+		n.dirsMu.Lock()
+		defer n.dirsMu.Unlock()
+		wd, err := syscall.InotifyAddWatch(n.fd, path, inotifyMask)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("could not watch %s: %w", path, err))
+			return nil
+		}
+		n.dirs[int32(wd)] = path
+		return nil
+	})
+
+	return errors.Join(errs...)
+}
+
+func (n *inotifyNotifier) close() error { return n.file.Close() }
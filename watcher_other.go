@@ -0,0 +1,10 @@
+//go:build !linux
+
+package years
+
+import "errors"
+
+// newWatchNotifier is not implemented for systems other than Linux, so Watcher polls.
+func newWatchNotifier(chan<- struct{}, func(msg string)) (watchNotifier, error) {
+	return nil, errors.New("notifications are supported on Linux only")
+}
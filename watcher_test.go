@@ -0,0 +1,98 @@
+package years_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/amberpixels/years"
+	"github.com/expectto/be"
+)
+
+// waitWatchEvent waits for the event of the given type for the given identifier (skipping other events).
+func waitWatchEvent(t *testing.T, w *years.Watcher, eventType years.WatchEventType, identifier string) years.WatchEvent {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event := <-w.Events():
+			if event.Type == eventType && event.Waypoint.Identifier() == identifier {
+				return event
+			}
+		case <-timeout:
+			t.Fatalf("no %s event for %s", eventType, identifier)
+		}
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	modes := map[string][]years.WatchOption{
+		"notifications": {years.WithWatchInterval(20 * time.Millisecond)},
+		"polling":       {years.WithWatchInterval(20 * time.Millisecond), years.WithWatchPolling()},
+	}
+
+	for name, opts := range modes {
+		t.Run(name, func(t *testing.T) {
+			voyagerSetup(t, "2006-01-02")
+			root := filepath.Join(t.TempDir(), "calendar")
+			writeFiles(t, root, "2024/Mar/2024-03-01.txt")
+
+			w, err := years.NewTimeNamedWatcher(root, "2006/Jan/2006-01-02.txt", opts...)
+			be.Require(t, err).To(be.Succeed())
+			t.Cleanup(func() { be.Expect(t, w.Close()).To(be.Succeed()) })
+			v := w.Voyager()
+
+			found, err := v.Navigate("2024-03-05")
+			be.Require(t, err).To(be.Succeed())
+			be.Expect(t, found).To(be.Nil())
+
+			// new file in a new directory
+			created := filepath.Join(root, "2024", "Apr", "2024-04-02.txt")
+			writeFiles(t, root, "2024/Apr/2024-04-02.txt")
+			event := waitWatchEvent(t, w, years.WatchCreated, created)
+			be.Expect(t, event.Time.Equal(time.Date(2024, time.April, 2, 0, 0, 0, 0, time.UTC))).To(be.True())
+
+			found, err = v.Navigate("2024-04-02")
+			be.Require(t, err).To(be.Succeed())
+			be.Require(t, found).NotTo(be.Nil())
+			be.Expect(t, found.Identifier()).To(be.Eq(created))
+
+			// renaming is removing + creating
+			renamed := filepath.Join(root, "2024", "Mar", "2024-03-05.txt")
+			be.Require(t, os.Rename(filepath.Join(root, "2024", "Mar", "2024-03-01.txt"), renamed)).To(be.Succeed())
+			waitWatchEvent(t, w, years.WatchRemoved, filepath.Join(root, "2024", "Mar", "2024-03-01.txt"))
+			waitWatchEvent(t, w, years.WatchCreated, renamed)
+
+			found, err = v.Navigate("2024-03-05")
+			be.Require(t, err).To(be.Succeed())
+			be.Require(t, found).NotTo(be.Nil())
+			be.Expect(t, found.Identifier()).To(be.Eq(renamed))
+
+			be.Require(t, os.Remove(created)).To(be.Succeed())
+			event = waitWatchEvent(t, w, years.WatchRemoved, created)
+			be.Expect(t, event.Time.Equal(time.Date(2024, time.April, 2, 0, 0, 0, 0, time.UTC))).To(be.True())
+
+			// files of a directory created after watching has started are noticed as well
+			may := filepath.Join(root, "2024", "May")
+			be.Require(t, os.Mkdir(may, 0o755)).To(be.Succeed())
+			waitWatchEvent(t, w, years.WatchCreated, may)
+			writeFiles(t, root, "2024/May/2024-05-01.txt")
+			waitWatchEvent(t, w, years.WatchCreated, filepath.Join(may, "2024-05-01.txt"))
+		})
+	}
+}
+
+func TestWatcher_Close(t *testing.T) {
+	root := t.TempDir()
+	w, err := years.NewWatcher(root, func() (years.Waypoint, error) {
+		return years.NewWaypointGroup(root), nil
+	})
+	be.Require(t, err).To(be.Succeed())
+
+	be.Expect(t, w.Close()).To(be.Succeed())
+	be.Expect(t, w.Close()).To(be.Succeed())
+
+	_, open := <-w.Events()
+	be.Expect(t, open).To(be.False())
+}
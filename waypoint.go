@@ -32,3 +32,32 @@ func AllChildren(w Waypoint) []Waypoint {
 
 	return result
 }
+
+// SpanWaypoint is an optional interface for waypoints standing for a time span rather than an instant,
+// e.g. a "2024/Mar" directory stands for the whole March of 2024.
+// Voyager uses it (when available) for range queries and for pruning navigation.
+type SpanWaypoint interface {
+	Waypoint
+
+	// Start returns the beginning of the span (inclusive). Usually it's the same as Time().
+	Start() time.Time
+
+	// End returns the end of the span (exclusive).
+	// It equals Start() for instant waypoints and for the ones whose span is unknown.
+	End() time.Time
+}
+
+// SpanOf returns the period the waypoint stands for, if it's known (see SpanWaypoint).
+func SpanOf(w Waypoint) (Period, bool) {
+	sw, ok := w.(SpanWaypoint)
+	if !ok {
+		return Period{}, false
+	}
+
+	start, end := sw.Start(), sw.End()
+	if start.IsZero() || !end.After(start) {
+		return Period{}, false
+	}
+
+	return Period{Start: start, End: end}, true
+}
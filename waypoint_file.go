@@ -2,9 +2,10 @@ package years
 
 import (
 	"fmt"
-	"log/slog"
+	"io/fs"
 	"os"
-	"path/filepath"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/djherbis/times"
@@ -19,65 +20,258 @@ type WaypointFile struct {
 	// fileInfo holds the file info for the given file
 	fileInfo os.FileInfo
 
+	// fsys is the file system the waypoint is read from (nil means the OS one)
+	fsys fileSystem
+
 	// timeSpec holds cross-platform file time creation/modification/access/birth information
 	timeSpec times.Timespec
 
 	// t is the time of the waypoint
 	t time.Time
 
+	// timeSource is the source the time was taken from (it's set for waypoints built via time sources only)
+	timeSource TimeSource
+
 	// Waypoints are inner children (subdirectories, files, etc)
 	waypoints []Waypoint
+
+	// depth is the nesting level of the waypoint in the scanned tree (0 for the root)
+	depth int
+
+	// descent are real paths of the directory and its ancestors in the scanned tree
+	// (it's set for SymlinksDetectCycles policy only)
+	descent []string
+
+	// loadChildren reads inner children on demand (it's set for lazy waypoints only)
+	loadChildren func() []Waypoint
+	// cacheChildren means lazily loaded children are kept, so they are read only once
+	cacheChildren bool
+	// loaded tells if lazily loaded children are cached already
+	loaded bool
+	mu     sync.Mutex
 }
 
 type WaypointFiles []*WaypointFile
 
-func (w *WaypointFile) Time() time.Time      { return w.t }
-func (w *WaypointFile) Identifier() string   { return w.path }
-func (w *WaypointFile) IsContainer() bool    { return w.fileInfo.IsDir() }
-func (w *WaypointFile) Children() []Waypoint { return w.waypoints }
+func (w *WaypointFile) Time() time.Time    { return w.t }
+func (w *WaypointFile) Identifier() string { return w.path }
+func (w *WaypointFile) IsContainer() bool  { return w.fileInfo.IsDir() }
 
-func NewWaypointFile(path string, timeGetter func(timeSpec times.Timespec) time.Time) (*WaypointFile, error) {
-	stat, err := os.Stat(path)
-	if err != nil {
-		return nil, fmt.Errorf("could not os.Stat file: %w", err)
+// open opens the file via the file system the waypoint is read from.
+func (w *WaypointFile) open() (fs.File, error) {
+	if w.fsys == nil {
+		return os.Open(w.path)
+	}
+	return w.fsys.Open(w.path)
+}
+
+// TimeSource returns the source the waypoint's time was taken from (see NewWaypointFileWithSources).
+// It's nil if no source could tell the time or if the waypoint was built without time sources.
+func (w *WaypointFile) TimeSource() TimeSource { return w.timeSource }
+
+// Children returns inner children of the directory.
+// For lazy waypoints (see WithLazyChildren) children are read on the first call.
+func (w *WaypointFile) Children() []Waypoint {
+	if w.loadChildren == nil {
+		return w.waypoints
 	}
 
-	timeSpec, err := times.Stat(path)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.loaded {
+		return w.waypoints
+	}
+
+	children := w.loadChildren()
+	if w.cacheChildren {
+		w.waypoints = children
+		w.loaded = true
+	}
+
+	return children
+}
+
+// descend sets nesting information of the waypoint being a child of the given parent (nil for the root).
+func (w *WaypointFile) descend(parent *WaypointFile, config *fileConfig) {
+	if parent != nil {
+		w.depth = parent.depth + 1
+	}
+
+	if config.scan.Symlinks != SymlinksDetectCycles || !w.fileInfo.IsDir() {
+		return
+	}
+	realPath, err := config.fs.EvalSymlinks(w.path)
 	if err != nil {
-		return nil, fmt.Errorf("could not times.Stat file: %w", err)
+		realPath = w.path
+	}
+	var ancestors []string
+	if parent != nil {
+		ancestors = parent.descent
 	}
+	w.descent = append(slices.Clip(ancestors), realPath)
+}
 
-	w := &WaypointFile{path: path, fileInfo: stat, timeSpec: timeSpec, t: timeGetter(timeSpec)}
+// setChildren sets children of the directory waypoint: they are read immediately or lazily, depending on config.
+// newChild creates a child waypoint for the given inner path.
+func (w *WaypointFile) setChildren(config *fileConfig, newChild func(innerPath string) (Waypoint, error)) error {
+	if !w.fileInfo.IsDir() {
+		return nil
+	}
 
-	if stat.IsDir() {
-		// Go deeper in the directory
-		innerPaths, err := filepath.Glob(filepath.Join(w.path, "*"))
+	if config.scan.MaxDepth > 0 && w.depth >= config.scan.MaxDepth {
+		return nil
+	}
+
+	if !config.lazy {
+		children, err := readChildren(w.path, w.descent, newChild, config)
 		if err != nil {
-			return nil, err
+			return err
+		}
+		w.waypoints = children
+		return nil
+	}
+
+	w.cacheChildren = config.cacheChildren
+	w.loadChildren = func() []Waypoint {
+		// Children() can't return an error, so halting means just stopping reading the directory
+		children, err := readChildren(w.path, w.descent, newChild, config)
+		if err != nil {
+			config.log(fmt.Sprintf("lazy: reading children of %s halted: %s", w.path, err))
+		}
+		return children
+	}
+
+	return nil
+}
+
+// readChildren reads the directory and creates its children sorted in Past->Future order.
+// Entries are filtered according to config's scan options (descent are real paths of the directory
+// and its ancestors, see SymlinksDetectCycles).
+// Errors are handled according to config's error policy: if they halt the reading,
+// children created so far are returned along with the error.
+func readChildren(
+	dirPath string, descent []string, newChild func(innerPath string) (Waypoint, error),
+	config *fileConfig,
+) ([]Waypoint, error) {
+	// Go deeper in the directory
+	entries, err := config.fs.ReadDir(dirPath)
+	if err != nil {
+		return nil, config.handleError(dirPath, err)
+	}
+
+	children := make([]Waypoint, 0, len(entries))
+	for _, entry := range entries {
+		innerPath := config.fs.Join(dirPath, entry.Name())
+		if !config.scan.acceptsEntry(entry) {
+			continue
+		}
+		if entry.Type()&fs.ModeSymlink != 0 && !config.scan.acceptsSymlink(config.fs, descent, dirPath, innerPath) {
+			continue
 		}
 
-		for _, innerPath := range innerPaths {
-			child, err := NewWaypointFile(innerPath, timeGetter)
-			if err != nil {
-				// TODO(nice-to-have): add configurable way to halt on child error, to log/omit errors, etc
-				slog.Info(fmt.Sprintf("child: NewWaypointFile(%s) failed: %s\n", innerPath, err))
-				continue
+		child, err := newChild(innerPath)
+		if err != nil {
+			if err := config.handleError(innerPath, err); err != nil {
+				return children, err
 			}
+			continue
+		}
+		if !config.scan.acceptsWaypoint(child) {
+			continue
+		}
 
-			// By default, let's sort nodes in Past->Future order
-			var inserted bool
-			for i, existed := range w.waypoints {
-				if existed.Time().After(child.t) {
-					w.waypoints = append(w.waypoints[:i+1], w.waypoints[i:]...)
-					w.waypoints[i] = child
-					inserted = true
-					break
-				}
+		// By default, let's sort nodes in Past->Future order
+		var inserted bool
+		for i, existed := range children {
+			if existed.Time().After(child.Time()) {
+				children = append(children[:i+1], children[i:]...)
+				children[i] = child
+				inserted = true
+				break
 			}
-			if !inserted {
-				w.waypoints = append(w.waypoints, child)
+		}
+		if !inserted {
+			children = append(children, child)
+		}
+	}
+
+	return children, nil
+}
+
+// NewWaypointFile creates a waypoint for the given path, taking the time from file's times via timeGetter.
+// Directories are recursed, building the whole tree (see WithLazyChildren for reading it on demand).
+// With FileErrorsCollect policy, the tree is returned along with all collected errors joined.
+func NewWaypointFile(
+	path string, timeGetter func(timeSpec times.Timespec) time.Time,
+	opts ...FileOption,
+) (*WaypointFile, error) {
+	timeOf := func(file SourceFile) (time.Time, TimeSource) { return timeGetter(file.Timespec), nil }
+
+	config := newFileConfig(opts...)
+	w, err := newWaypointFile(path, timeOf, nil, config)
+	if err != nil {
+		return nil, unwrapHalt(err)
+	}
+
+	return w, config.collectedErrors()
+}
+
+// NewWaypointFileFS creates a waypoint for the given path in the given file system (e.g. embed.FS).
+// If the file system doesn't provide OS file times, all times passed to timeGetter are the modification time.
+func NewWaypointFileFS(
+	fsys fs.FS, path string, timeGetter func(timeSpec times.Timespec) time.Time,
+	opts ...FileOption,
+) (*WaypointFile, error) {
+	return NewWaypointFile(path, timeGetter, append([]FileOption{withFS(fsys)}, opts...)...)
+}
+
+// NewWaypointFileWithSources creates a waypoint for the given path, taking the time from the first of the given
+// sources that can tell it (see TimeSource and DefaultTimeSources). The used source is recorded on each waypoint
+// (see WaypointFile.TimeSource). Files no source can tell the time of have zero time.
+func NewWaypointFileWithSources(path string, sources []TimeSource, opts ...FileOption) (*WaypointFile, error) {
+	config := newFileConfig(opts...)
+	timeOf := func(file SourceFile) (time.Time, TimeSource) {
+		file.RelPath, _ = config.fs.Rel(path, file.Path)
+		for _, source := range sources {
+			if t, ok := source.Time(file); ok {
+				return t, source
 			}
 		}
+		return time.Time{}, nil
+	}
+
+	w, err := newWaypointFile(path, timeOf, nil, config)
+	if err != nil {
+		return nil, unwrapHalt(err)
+	}
+
+	return w, config.collectedErrors()
+}
+
+func newWaypointFile(
+	path string, timeOf func(file SourceFile) (time.Time, TimeSource),
+	parent *WaypointFile, config *fileConfig,
+) (*WaypointFile, error) {
+	stat, err := config.fs.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not stat file: %w", err)
+	}
+
+	timeSpec, err := config.fs.Timespec(path, stat)
+	if err != nil {
+		return nil, fmt.Errorf("could not get file times: %w", err)
+	}
+
+	w := &WaypointFile{path: path, fileInfo: stat, fsys: config.fs, timeSpec: timeSpec}
+	w.descend(parent, config)
+	w.t, w.timeSource = timeOf(SourceFile{Path: path, Info: stat, Timespec: timeSpec, fs: config.fs})
+
+	err = w.setChildren(config, func(innerPath string) (Waypoint, error) {
+		return newWaypointFile(innerPath, timeOf, w, config)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return w, nil
@@ -0,0 +1,450 @@
+package years
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/djherbis/times"
+)
+
+// archiveFormat is a format of archives file waypoints can descend into (see WithArchives).
+type archiveFormat int
+
+const (
+	archiveNone archiveFormat = iota
+	archiveTar
+	archiveTarGz
+	archiveZip
+)
+
+// archiveFormatOf detects the archive format by the file name.
+func archiveFormatOf(name string) archiveFormat {
+	switch strings.ToLower(archiveExtension(name)) {
+	case ".tar":
+		return archiveTar
+	case ".tar.gz", ".tgz":
+		return archiveTarGz
+	case ".zip":
+		return archiveZip
+	default:
+		return archiveNone
+	}
+}
+
+// archiveExtension returns the archive extension of the file name (e.g. ".tar.gz"), or "" if it's not an archive.
+func archiveExtension(name string) string {
+	lower := strings.ToLower(name)
+	for _, ext := range []string{".tar.gz", ".tgz", ".tar", ".zip"} {
+		if strings.HasSuffix(lower, ext) {
+			return name[len(name)-len(ext):]
+		}
+	}
+	return ""
+}
+
+// archiveFileSystem is the fileSystem descending into archives found in the base one:
+// archives are seen as directories, and their entries are available under the archive's path,
+// e.g. "logs/2024-03.tar.gz/2024-03-05.log".
+// Archives are never extracted: only headers of their entries are read (once per archive),
+// entries' content is read on Open only. Archives nested in archives are seen as regular files.
+type archiveFileSystem struct {
+	base fileSystem
+
+	indexes   map[string]*archiveIndex
+	indexesMu sync.Mutex
+}
+
+func newArchiveFileSystem(base fileSystem) *archiveFileSystem {
+	return &archiveFileSystem{base: base, indexes: make(map[string]*archiveIndex)}
+}
+
+// split splits the path into the path of the archive (in the base file system) and the path inside it.
+// It returns ok=false if the path is not an archive nor is inside one.
+func (s *archiveFileSystem) split(name string) (archivePath string, innerPath string, ok bool) {
+	// ToSlash keeps the length, so indexes in the slashed path are valid for the original one
+	slashed := filepath.ToSlash(name)
+	for end := 0; end < len(slashed); {
+		next := strings.IndexByte(slashed[end+1:], '/')
+		if next < 0 {
+			next = len(slashed)
+		} else {
+			next += end + 1
+		}
+
+		if archiveFormatOf(slashed[end:next]) != archiveNone {
+			info, err := s.base.Stat(name[:next])
+			if err == nil && info.Mode().IsRegular() {
+				inner := strings.TrimPrefix(slashed[next:], "/")
+				if inner == "" {
+					inner = "."
+				}
+				return name[:next], inner, true
+			}
+		}
+		end = next
+	}
+
+	return "", "", false
+}
+
+// index returns the (cached) index of the archive.
+func (s *archiveFileSystem) index(archivePath string) (*archiveIndex, error) {
+	s.indexesMu.Lock()
+	defer s.indexesMu.Unlock()
+
+	if index, ok := s.indexes[archivePath]; ok {
+		return index, nil
+	}
+
+	index, err := readArchiveIndex(s.base, archivePath)
+	if err != nil {
+		return nil, err
+	}
+	s.indexes[archivePath] = index
+
+	return index, nil
+}
+
+func (s *archiveFileSystem) Stat(name string) (fs.FileInfo, error) {
+	archivePath, innerPath, ok := s.split(name)
+	if !ok {
+		return s.base.Stat(name)
+	}
+
+	if innerPath == "." {
+		info, err := s.base.Stat(archivePath)
+		if err != nil {
+			return nil, err
+		}
+		return archiveRootInfo{info}, nil
+	}
+
+	index, err := s.index(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	entry, ok := index.entries[innerPath]
+	if !ok {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+
+	return entry, nil
+}
+
+// Timespec returns real file times for archives themselves and header times for their entries.
+func (s *archiveFileSystem) Timespec(name string, info fs.FileInfo) (times.Timespec, error) {
+	archivePath, innerPath, ok := s.split(name)
+	if ok && innerPath != "." {
+		return fileInfoTimespec(info), nil
+	}
+	if ok {
+		name = archivePath
+	}
+	if root, isRoot := info.(archiveRootInfo); isRoot {
+		info = root.FileInfo
+	}
+
+	return s.base.Timespec(name, info)
+}
+
+func (s *archiveFileSystem) ReadDir(name string) ([]fs.DirEntry, error) {
+	archivePath, innerPath, ok := s.split(name)
+	if !ok {
+		entries, err := s.base.ReadDir(name)
+		if err != nil {
+			return nil, err
+		}
+
+		// archives are directories here
+		for i, entry := range entries {
+			if entry.Type().IsRegular() && archiveFormatOf(entry.Name()) != archiveNone {
+				entries[i] = archiveRootEntry{entry}
+			}
+		}
+		return entries, nil
+	}
+
+	index, err := s.index(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	entry, ok := index.entries[innerPath]
+	if innerPath != "." && (!ok || !entry.IsDir()) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+
+	children := index.children[innerPath]
+	entries := make([]fs.DirEntry, 0, len(children))
+	for _, child := range children {
+		entries = append(entries, fs.FileInfoToDirEntry(child))
+	}
+	return entries, nil
+}
+
+func (s *archiveFileSystem) Open(name string) (fs.File, error) {
+	archivePath, innerPath, ok := s.split(name)
+	if !ok {
+		return s.base.Open(name)
+	}
+	if innerPath == "." {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	index, err := s.index(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	entry, ok := index.entries[innerPath]
+	if !ok || entry.IsDir() {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+
+	return openArchiveEntry(s.base, archivePath, index.format, entry)
+}
+
+func (s *archiveFileSystem) Join(elem ...string) string { return s.base.Join(elem...) }
+func (s *archiveFileSystem) Rel(basePath, targetPath string) (string, error) {
+	return s.base.Rel(basePath, targetPath)
+}
+
+// EvalSymlinks returns paths inside archives as is (links are not kept in archive indexes).
+func (s *archiveFileSystem) EvalSymlinks(name string) (string, error) {
+	if _, _, ok := s.split(name); ok {
+		return name, nil
+	}
+	return s.base.EvalSymlinks(name)
+}
+
+// archiveRootInfo is the file info of an archive seen as a directory.
+type archiveRootInfo struct{ fs.FileInfo }
+
+func (archiveRootInfo) IsDir() bool         { return true }
+func (i archiveRootInfo) Mode() fs.FileMode { return i.FileInfo.Mode()&fs.ModePerm | fs.ModeDir }
+
+// archiveRootEntry is the directory entry of an archive seen as a directory.
+type archiveRootEntry struct{ fs.DirEntry }
+
+func (archiveRootEntry) IsDir() bool       { return true }
+func (archiveRootEntry) Type() fs.FileMode { return fs.ModeDir }
+func (e archiveRootEntry) Info() (fs.FileInfo, error) {
+	info, err := e.DirEntry.Info()
+	if err != nil {
+		return nil, err
+	}
+	return archiveRootInfo{info}, nil
+}
+
+// archiveEntry is an entry (a regular file or a directory) of an archive. It's the entry's fs.FileInfo.
+type archiveEntry struct {
+	// name is the entry's path inside the archive (slash-separated, see fs.ValidPath)
+	name    string
+	size    int64
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+func (e *archiveEntry) Name() string       { return path.Base(e.name) }
+func (e *archiveEntry) Size() int64        { return e.size }
+func (e *archiveEntry) Mode() fs.FileMode  { return e.mode }
+func (e *archiveEntry) ModTime() time.Time { return e.modTime }
+func (e *archiveEntry) IsDir() bool        { return e.mode.IsDir() }
+func (e *archiveEntry) Sys() any           { return nil }
+
+// archiveIndex holds entries of an archive by their paths, along with children of every directory.
+// Directories missing in the archive (having entries inside though) are added with zero time.
+type archiveIndex struct {
+	format   archiveFormat
+	entries  map[string]*archiveEntry
+	children map[string][]*archiveEntry
+}
+
+// add adds the entry to the index along with its missing parent directories.
+// Entries having invalid paths (e.g. absolute or with ".." elements) are skipped.
+func (idx *archiveIndex) add(entry *archiveEntry) {
+	entry.name = path.Clean(strings.TrimPrefix(entry.name, "./"))
+	if entry.name == "." || !fs.ValidPath(entry.name) {
+		return
+	}
+
+	if existed, ok := idx.entries[entry.name]; ok {
+		// e.g. a directory added as a parent before its own entry, or a file appended to the archive again
+		*existed = *entry
+		return
+	}
+	idx.entries[entry.name] = entry
+
+	dir := path.Dir(entry.name)
+	if _, ok := idx.entries[dir]; !ok && dir != "." {
+		idx.add(&archiveEntry{name: dir, mode: fs.ModeDir | 0o755})
+	}
+	idx.children[dir] = append(idx.children[dir], entry)
+}
+
+// readArchiveIndex reads headers of all entries of the archive.
+func readArchiveIndex(fsys fileSystem, archivePath string) (*archiveIndex, error) {
+	index := &archiveIndex{
+		format:   archiveFormatOf(archivePath),
+		entries:  make(map[string]*archiveEntry),
+		children: make(map[string][]*archiveEntry),
+	}
+
+	f, err := fsys.Open(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open archive: %w", err)
+	}
+	defer f.Close()
+
+	switch index.format {
+	case archiveZip:
+		zr, err := newZipReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("could not read archive %s: %w", archivePath, err)
+		}
+		for _, file := range zr.File {
+			info := file.FileInfo()
+			if !info.Mode().IsRegular() && !info.IsDir() {
+				continue
+			}
+			index.add(&archiveEntry{name: file.Name, size: info.Size(), mode: info.Mode(), modTime: file.Modified})
+		}
+	case archiveTar, archiveTarGz:
+		tr, closeReader, err := newTarReader(f, index.format)
+		if err != nil {
+			return nil, fmt.Errorf("could not read archive %s: %w", archivePath, err)
+		}
+		defer closeReader()
+
+		for {
+			header, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("could not read archive %s: %w", archivePath, err)
+			}
+
+			info := header.FileInfo()
+			if !info.Mode().IsRegular() && !info.IsDir() {
+				continue
+			}
+			index.add(&archiveEntry{name: header.Name, size: info.Size(), mode: info.Mode(), modTime: header.ModTime})
+		}
+	case archiveNone:
+		return nil, fmt.Errorf("not an archive: %s", archivePath)
+	}
+
+	// entries are listed in the alphabetical order (as os.ReadDir does)
+	for _, children := range index.children {
+		slices.SortFunc(children, func(a, b *archiveEntry) int { return strings.Compare(a.name, b.name) })
+	}
+
+	return index, nil
+}
+
+// newZipReader creates a zip reader over the opened file. Files not supporting io.ReaderAt are read into memory.
+func newZipReader(f fs.File) (*zip.Reader, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	if readerAt, ok := f.(io.ReaderAt); ok {
+		return zip.NewReader(readerAt, info.Size())
+	}
+
+	content, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+	return zip.NewReader(bytes.NewReader(content), int64(len(content)))
+}
+
+// newTarReader creates a tar reader over the opened file, decompressing it if needed.
+func newTarReader(f fs.File, format archiveFormat) (*tar.Reader, func(), error) {
+	if format != archiveTarGz {
+		return tar.NewReader(f), func() {}, nil
+	}
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tar.NewReader(gr), func() { _ = gr.Close() }, nil
+}
+
+// openArchiveEntry opens the entry of the archive for reading.
+// For tar archives, the archive is read from the beginning up to the entry.
+func openArchiveEntry(fsys fileSystem, archivePath string, format archiveFormat, entry *archiveEntry) (fs.File, error) {
+	f, err := fsys.Open(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open archive: %w", err)
+	}
+
+	var reader io.Reader
+	closeReader := func() {}
+	switch format {
+	case archiveZip:
+		zr, err := newZipReader(f)
+		if err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("could not read archive %s: %w", archivePath, err)
+		}
+		rc, err := zr.Open(entry.name)
+		if err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+		reader, closeReader = rc, func() { _ = rc.Close() }
+	default:
+		tr, closeTar, err := newTarReader(f, format)
+		if err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("could not read archive %s: %w", archivePath, err)
+		}
+		for {
+			header, err := tr.Next()
+			if err != nil {
+				closeTar()
+				_ = f.Close()
+				if errors.Is(err, io.EOF) {
+					err = fs.ErrNotExist
+				}
+				return nil, fmt.Errorf("could not find %s in archive %s: %w", entry.name, archivePath, err)
+			}
+			if path.Clean(strings.TrimPrefix(header.Name, "./")) == entry.name {
+				break
+			}
+		}
+		reader, closeReader = tr, closeTar
+	}
+
+	return &archiveFile{Reader: reader, entry: entry, close: func() {
+		closeReader()
+		_ = f.Close()
+	}}, nil
+}
+
+// archiveFile is an opened entry of an archive.
+type archiveFile struct {
+	io.Reader
+	entry *archiveEntry
+	close func()
+}
+
+func (f *archiveFile) Stat() (fs.FileInfo, error) { return f.entry, nil }
+func (f *archiveFile) Close() error {
+	f.close()
+	return nil
+}
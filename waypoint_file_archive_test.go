@@ -0,0 +1,159 @@
+package years_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amberpixels/years"
+	"github.com/djherbis/times"
+	"github.com/expectto/be"
+)
+
+// archivedFile is a file to be written into a test archive.
+type archivedFile struct {
+	name    string
+	content string
+	modTime time.Time
+}
+
+// writeArchive writes the given files into an archive (its format is taken from the extension).
+func writeArchive(t *testing.T, path string, files ...archivedFile) {
+	t.Helper()
+	be.Require(t, os.MkdirAll(filepath.Dir(path), 0o755)).To(be.Succeed())
+	f, err := os.Create(path)
+	be.Require(t, err).To(be.Succeed())
+	defer f.Close()
+
+	if strings.HasSuffix(path, ".zip") {
+		zw := zip.NewWriter(f)
+		for _, file := range files {
+			w, err := zw.CreateHeader(&zip.FileHeader{Name: file.name, Modified: file.modTime, Method: zip.Deflate})
+			be.Require(t, err).To(be.Succeed())
+			_, err = io.WriteString(w, file.content)
+			be.Require(t, err).To(be.Succeed())
+		}
+		be.Require(t, zw.Close()).To(be.Succeed())
+		return
+	}
+
+	var w io.Writer = f
+	if strings.HasSuffix(path, ".gz") {
+		gw := gzip.NewWriter(f)
+		defer func() { be.Require(t, gw.Close()).To(be.Succeed()) }()
+		w = gw
+	}
+	tw := tar.NewWriter(w)
+	for _, file := range files {
+		header := &tar.Header{Name: file.name, Mode: 0o644, Size: int64(len(file.content)), ModTime: file.modTime}
+		be.Require(t, tw.WriteHeader(header)).To(be.Succeed())
+		_, err := io.WriteString(tw, file.content)
+		be.Require(t, err).To(be.Succeed())
+	}
+	be.Require(t, tw.Close()).To(be.Succeed())
+}
+
+func TestTimeNamedWaypointFile_Archives(t *testing.T) {
+	voyagerSetup(t, "2006-01-02")
+	root := filepath.Join(t.TempDir(), "logs")
+	writeFiles(t, root, "2024/2024-03/2024-03-05.log")
+	writeArchive(t, filepath.Join(root, "2024", "2024-01.tar.gz"),
+		archivedFile{name: "2024-01-01.log", content: "new year"},
+		archivedFile{name: "2024-01-15.log", content: "mid january"},
+	)
+	writeArchive(t, filepath.Join(root, "2024", "2024-02.zip"),
+		archivedFile{name: "2024-02-29.log", content: "leap day"},
+	)
+
+	wf, err := years.NewTimeNamedWaypointFileWithOptions(root, "2006/2006-01/2006-01-02.log", years.WithArchives())
+	be.Require(t, err).To(be.Succeed())
+
+	v := years.NewVoyager(wf)
+	be.Expect(t, collectTraverse(t, v, years.O_FUTURE(), years.O_LEAVES_ONLY())).To(be.Eq([]string{
+		filepath.Join(root, "2024", "2024-01.tar.gz", "2024-01-01.log"),
+		filepath.Join(root, "2024", "2024-01.tar.gz", "2024-01-15.log"),
+		filepath.Join(root, "2024", "2024-02.zip", "2024-02-29.log"),
+		filepath.Join(root, "2024", "2024-03", "2024-03-05.log"),
+	}))
+
+	w, err := v.Navigate("2024-01-15")
+	be.Require(t, err).To(be.Succeed())
+	be.Require(t, w).NotTo(be.Nil())
+	be.Expect(t, w.Identifier()).To(be.Eq(filepath.Join(root, "2024", "2024-01.tar.gz", "2024-01-15.log")))
+
+	// archives are months (as directories are)
+	archive, ok := years.SpanOf(wf.Children()[0].Children()[0])
+	be.Require(t, ok).To(be.True())
+	be.Expect(t, archive.Contains(time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC))).To(be.True())
+	be.Expect(t, archive.Contains(time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC))).To(be.False())
+}
+
+func TestWaypointFile_Archives(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "archives")
+	at := func(day int) time.Time { return time.Date(2024, time.March, day, 10, 0, 0, 0, time.UTC) }
+	writeArchive(t, filepath.Join(root, "march.tar"),
+		archivedFile{name: "b/second.txt", content: "2", modTime: at(2)},
+		archivedFile{name: "a/first.txt", content: "1", modTime: at(1)},
+		archivedFile{name: "./third.txt", content: "3", modTime: at(3)},
+	)
+	modTime := func(ts times.Timespec) time.Time { return ts.ModTime() }
+
+	t.Run("header times", func(t *testing.T) {
+		wf, err := years.NewWaypointFile(root, modTime, years.WithArchives())
+		be.Require(t, err).To(be.Succeed())
+		be.Require(t, wf.Children()).To(be.HaveLength(1))
+
+		archive := wf.Children()[0]
+		be.Expect(t, archive.IsContainer()).To(be.True())
+		// directories missing in the archive have zero time
+		be.Expect(t, childrenNames(archive)).To(be.Eq([]string{"a", "b", "third.txt"}))
+
+		leaves := collectTraverse(t, years.NewVoyager(wf), years.O_FUTURE(), years.O_LEAVES_ONLY())
+		be.Expect(t, leaves).To(be.Eq([]string{
+			filepath.Join(root, "march.tar", "a", "first.txt"),
+			filepath.Join(root, "march.tar", "b", "second.txt"),
+			filepath.Join(root, "march.tar", "third.txt"),
+		}))
+		be.Expect(t, archive.Children()[2].Time().Equal(at(3))).To(be.True())
+	})
+
+	t.Run("content", func(t *testing.T) {
+		writeArchive(t, filepath.Join(root, "notes.zip"),
+			archivedFile{name: "note.md", content: "---\ndate: 2024-03-05\n---\nhello", modTime: at(1)},
+		)
+		t.Cleanup(func() { _ = os.Remove(filepath.Join(root, "notes.zip")) })
+
+		wf, err := years.NewContentWaypointFile(root, years.FrontMatterDate(), years.WithArchives())
+		be.Require(t, err).To(be.Succeed())
+
+		var found bool
+		for w := range years.NewVoyager(wf).All(years.O_LEAVES_ONLY()) {
+			if filepath.Base(w.Identifier()) == "note.md" {
+				found = true
+				be.Expect(t, w.Time().Equal(time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC))).To(be.True())
+			}
+		}
+		be.Expect(t, found).To(be.True())
+	})
+
+	t.Run("include patterns", func(t *testing.T) {
+		wf, err := years.NewWaypointFile(root, modTime,
+			years.WithArchives(), years.WithScanOptions(years.ScanOptions{Include: []string{"*.txt"}}),
+		)
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, childrenNames(wf)).To(be.Eq([]string{"march.tar"}))
+	})
+
+	t.Run("without archives option", func(t *testing.T) {
+		wf, err := years.NewWaypointFile(root, modTime)
+		be.Require(t, err).To(be.Succeed())
+		be.Require(t, wf.Children()).To(be.HaveLength(1))
+		be.Expect(t, wf.Children()[0].IsContainer()).To(be.False())
+	})
+}
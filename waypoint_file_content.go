@@ -0,0 +1,171 @@
+package years
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ContentExtractor extracts the time from the content (a bounded prefix) of a file.
+// The given parser is to be used for the actual parsing, so parser defaults are respected.
+type ContentExtractor func(content []byte, parser *Parser) (time.Time, bool)
+
+// DefaultContentMaxBytes is the default limit of bytes read from files by content extractors.
+const DefaultContentMaxBytes = 4096
+
+// NewContentWaypointFile creates a waypoint for the given path, taking the time of files from their contents
+// via the given extractor (e.g. FrontMatterDate or FirstLineTimestamp). Only DefaultContentMaxBytes are read.
+// Directories and files the time can't be extracted from are non calendar (zero time).
+// See TimeFromContent for combining it with other time sources.
+func NewContentWaypointFile(path string, extractor ContentExtractor, opts ...FileOption) (*WaypointFile, error) {
+	return NewWaypointFileWithSources(path, []TimeSource{TimeFromContent(extractor, DefaultContentMaxBytes)}, opts...)
+}
+
+// TimeFromContent tells the time from the first maxBytes of the file via the given extractor.
+// maxBytes <= 0 means DefaultContentMaxBytes.
+func TimeFromContent(extractor ContentExtractor, maxBytes int) TimeSource {
+	return newContentTimeSource(TimeSourceContent, extractor, maxBytes)
+}
+
+func newContentTimeSource(name string, extractor ContentExtractor, maxBytes int) TimeSource {
+	if maxBytes <= 0 {
+		maxBytes = DefaultContentMaxBytes
+	}
+
+	return NewTimeSource(name, func(file SourceFile) (time.Time, bool) {
+		if file.Info.IsDir() {
+			return time.Time{}, false
+		}
+
+		f, err := file.Open()
+		if err != nil {
+			return time.Time{}, false
+		}
+		defer f.Close()
+
+		content, err := io.ReadAll(io.LimitReader(f, int64(maxBytes)))
+		if err != nil {
+			return time.Time{}, false
+		}
+
+		// Default parser is used. Use years.SetParserDefaults to configure parsing
+		return extractor(content, NewParser())
+	})
+}
+
+// FrontMatterDate extracts the time from the front-matter: YAML (between "---" lines)
+// or TOML (between "+++" lines). Values of the given keys ("date" by default) are tried in the given order.
+// Only flat `key: value` (or `key = value`) lines are supported.
+func FrontMatterDate(keys ...string) ContentExtractor {
+	if len(keys) == 0 {
+		keys = []string{"date"}
+	}
+
+	return func(content []byte, parser *Parser) (time.Time, bool) {
+		values := parseFrontMatter(content)
+		for _, key := range keys {
+			value, ok := values[key]
+			if !ok || value == "" {
+				continue
+			}
+			if t, err := parser.JustParse(value); err == nil {
+				return t, true
+			}
+		}
+
+		return time.Time{}, false
+	}
+}
+
+// parseFrontMatter returns flat key-values of the front-matter of the given content (nil if there is none).
+func parseFrontMatter(content []byte) map[string]string {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	if !scanner.Scan() {
+		return nil
+	}
+
+	var separator string
+	delimiter := strings.TrimSpace(scanner.Text())
+	switch delimiter {
+	case "---":
+		separator = ":"
+	case "+++":
+		separator = "="
+	default:
+		return nil
+	}
+
+	values := make(map[string]string)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == delimiter || (delimiter == "---" && line == "...") {
+			break
+		}
+
+		key, value, found := strings.Cut(line, separator)
+		if !found {
+			continue
+		}
+		values[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"'`)
+	}
+
+	return values
+}
+
+// FirstLineTimestamp extracts the time from the beginning of the first non-empty line,
+// e.g. "2024-03-05 10:11:12 INFO started" or "[2024-03-05T10:11:12Z] started".
+// Optional layout is the layout of the timestamp (all parser's layouts are tried by default).
+// Numeric timestamps are accepted only via timestamp layouts, e.g. "U@" for "1709632800 INFO started".
+func FirstLineTimestamp(layoutArg ...string) ContentExtractor {
+	var layout string
+	if len(layoutArg) > 0 {
+		layout = layoutArg[0]
+	}
+	acceptEpochs := strings.Contains(layout, LayoutTimestampSeconds)
+
+	return func(content []byte, parser *Parser) (time.Time, bool) {
+		if !acceptEpochs {
+			parser = parser.withoutEpochs()
+		}
+
+		for line := range strings.Lines(string(content)) {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			return parseLinePrefix(line, parser, layout)
+		}
+
+		return time.Time{}, false
+	}
+}
+
+// lineTimestampMaxFields is the max number of whitespace-separated fields a line's timestamp can consist of
+// (e.g. "Mon Jan 2 15:04:05 MST 2006" is six fields).
+const lineTimestampMaxFields = 6
+
+// parseLinePrefix parses the time the given line starts with (optionally in brackets)
+// via the given layout (or via all parser's layouts if it's empty).
+// The longest prefix of whitespace-separated fields that the parser accepts wins.
+func parseLinePrefix(line string, parser *Parser, layout string) (time.Time, bool) {
+	line = strings.TrimLeft(strings.TrimSpace(line), "[(")
+	fields := strings.Fields(line)
+	if len(fields) > lineTimestampMaxFields {
+		fields = fields[:lineTimestampMaxFields]
+	}
+
+	for n := len(fields); n > 0; n-- {
+		value := strings.TrimRight(strings.Join(fields[:n], " "), "]),;")
+		// values without digits are skipped, so words like "now" are not taken as aliases
+		if !strings.ContainsFunc(value, unicode.IsDigit) {
+			continue
+		}
+		if t, err := parser.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
@@ -0,0 +1,120 @@
+package years
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/djherbis/times"
+)
+
+// fileSystem abstracts the file system access of file waypoints,
+// so they can be built over the OS file system as well as over any fs.FS.
+type fileSystem interface {
+	Stat(name string) (fs.FileInfo, error)
+	Timespec(name string, info fs.FileInfo) (times.Timespec, error)
+	ReadDir(name string) ([]fs.DirEntry, error)
+	Open(name string) (fs.File, error)
+	Join(elem ...string) string
+	Rel(basePath, targetPath string) (string, error)
+	EvalSymlinks(name string) (string, error)
+}
+
+// osFileSystem is the fileSystem of the OS (default one).
+type osFileSystem struct{}
+
+func (osFileSystem) Stat(name string) (fs.FileInfo, error) { return os.Stat(name) }
+func (osFileSystem) Timespec(name string, _ fs.FileInfo) (times.Timespec, error) {
+	return times.Stat(name)
+}
+func (osFileSystem) ReadDir(name string) ([]fs.DirEntry, error) { return os.ReadDir(name) }
+func (osFileSystem) Open(name string) (fs.File, error)          { return os.Open(name) }
+func (osFileSystem) Join(elem ...string) string                 { return filepath.Join(elem...) }
+func (osFileSystem) Rel(basePath, targetPath string) (string, error) {
+	return filepath.Rel(basePath, targetPath)
+}
+func (osFileSystem) EvalSymlinks(name string) (string, error) { return filepath.EvalSymlinks(name) }
+
+// ioFileSystem is the fileSystem over the given fs.FS. Its paths are slash-separated (see fs.ValidPath).
+type ioFileSystem struct{ fsys fs.FS }
+
+func (s ioFileSystem) Stat(name string) (fs.FileInfo, error) { return fs.Stat(s.fsys, name) }
+func (s ioFileSystem) Timespec(_ string, info fs.FileInfo) (times.Timespec, error) {
+	return fileInfoTimespec(info), nil
+}
+func (s ioFileSystem) ReadDir(name string) ([]fs.DirEntry, error) { return fs.ReadDir(s.fsys, name) }
+func (s ioFileSystem) Open(name string) (fs.File, error)          { return s.fsys.Open(name) }
+func (s ioFileSystem) Join(elem ...string) string                 { return path.Join(elem...) }
+
+// Rel works for valid fs.FS paths only: they are clean and never contain ".." elements.
+func (s ioFileSystem) Rel(basePath, targetPath string) (string, error) {
+	switch {
+	case basePath == targetPath:
+		return ".", nil
+	case basePath == ".":
+		return targetPath, nil
+	case strings.HasPrefix(targetPath, basePath+"/"):
+		return strings.TrimPrefix(targetPath, basePath+"/"), nil
+	default:
+		return "", fmt.Errorf("%s is not inside %s", targetPath, basePath)
+	}
+}
+
+// EvalSymlinks resolves links of the final path element only (fs.FS has no notion of real paths).
+// Paths are returned as is if the fs.FS doesn't support links (see fs.ReadLinkFS).
+func (s ioFileSystem) EvalSymlinks(name string) (string, error) {
+	const maxLinks = 255 // same as filepath.EvalSymlinks
+
+	for range maxLinks {
+		info, err := fs.Lstat(s.fsys, name)
+		if err != nil {
+			return "", err
+		}
+		if info.Mode()&fs.ModeSymlink == 0 {
+			return name, nil
+		}
+
+		target, err := fs.ReadLink(s.fsys, name)
+		if err != nil {
+			return "", err
+		}
+		name = path.Join(path.Dir(name), target)
+	}
+
+	return "", errors.New("too many links")
+}
+
+// fileInfoTimespec returns file times from the given file info.
+// If the info doesn't hold the OS stat (e.g. it's from embed.FS or fstest.MapFS),
+// all times fall back to the modification time (see modTimespec).
+func fileInfoTimespec(info fs.FileInfo) (ts times.Timespec) {
+	if info.Sys() == nil {
+		return modTimespec(info.ModTime())
+	}
+
+	// times.Get panics if Sys() is not the stat of the current platform
+	defer func() {
+		if recover() != nil {
+			ts = modTimespec(info.ModTime())
+		}
+	}()
+
+	return times.Get(info)
+}
+
+// modTimespec is a times.Timespec having the modification time only:
+// all other times are the modification time as well.
+// HasChangeTime and HasBirthTime report false, though ChangeTime and BirthTime don't panic.
+type modTimespec time.Time
+
+func (t modTimespec) ModTime() time.Time    { return time.Time(t) }
+func (t modTimespec) AccessTime() time.Time { return time.Time(t) }
+func (t modTimespec) ChangeTime() time.Time { return time.Time(t) }
+func (t modTimespec) BirthTime() time.Time  { return time.Time(t) }
+func (modTimespec) HasChangeTime() bool     { return false }
+func (modTimespec) HasBirthTime() bool      { return false }
@@ -0,0 +1,180 @@
+package years
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"sync"
+)
+
+// FileErrorPolicy specifies how errors met while building children of file waypoints are handled
+// (e.g. a file that can't be stat-ed).
+type FileErrorPolicy string
+
+const (
+	// FileErrorsLog logs the error and skips the failed child.
+	FileErrorsLog FileErrorPolicy = "log"
+	// FileErrorsHalt stops building the tree and returns the error.
+	FileErrorsHalt FileErrorPolicy = "halt"
+	// FileErrorsCollect skips the failed child and returns all errors joined alongside the tree.
+	FileErrorsCollect FileErrorPolicy = "collect"
+)
+
+// fileConfig holds configuration for building file waypoints.
+type fileConfig struct {
+	// fs is the file system to read files from (the OS one by default)
+	fs fileSystem
+
+	lazy          bool
+	cacheChildren bool
+
+	scan ScanOptions
+
+	// archives means descending into archives (see WithArchives)
+	archives bool
+
+	errorPolicy  FileErrorPolicy
+	errorHandler func(path string, err error) error
+	logger       *slog.Logger
+
+	// errs are the errors collected with FileErrorsCollect policy
+	errs   []error
+	errsMu sync.Mutex
+}
+
+// defaultFileConfig is the OS file system + eager loading (lazily loaded children would be cached) + logging errors.
+func defaultFileConfig() *fileConfig {
+	return &fileConfig{fs: osFileSystem{}, cacheChildren: true, errorPolicy: FileErrorsLog}
+}
+
+// newFileConfig builds a fileConfig from the defaults and given options.
+func newFileConfig(opts ...FileOption) *fileConfig {
+	config := defaultFileConfig()
+	for _, opt := range opts {
+		opt(config)
+	}
+	if config.archives {
+		config.fs = newArchiveFileSystem(config.fs)
+	}
+	return config
+}
+
+// FileOption defines functional options for file waypoints constructors.
+type FileOption func(*fileConfig)
+
+// WithLazyChildren opts to read directory children only when Children() is called for the first time,
+// instead of recursing the whole directory tree at construction.
+func WithLazyChildren() FileOption {
+	return func(c *fileConfig) { c.lazy = true }
+}
+
+// WithoutChildrenCache opts to re-read lazily loaded children on every Children() call,
+// so the tree reflects changes on the disk. It's meaningful with WithLazyChildren only.
+func WithoutChildrenCache() FileOption {
+	return func(c *fileConfig) { c.cacheChildren = false }
+}
+
+// WithScanOptions opts to filter directory entries while scanning (see ScanOptions).
+func WithScanOptions(scan ScanOptions) FileOption {
+	return func(c *fileConfig) { c.scan = scan }
+}
+
+// WithArchives opts to descend into tar, tar.gz (tgz) and zip archives as if they were directories,
+// so their entries become children waypoints (e.g. "logs/2024-03.tar.gz/2024-03-05.log").
+// Archives are not extracted: only headers of entries are read, and their times are header modification times
+// (so e.g. NewTimeNamedWaypointFile can still take times from names, and NewWaypointFile from headers).
+// For time named waypoints, archive names not matching the layout are parsed without the extension,
+// so "2024/03.tar.gz" archive stands for "2024/03" directory of "2006/01/2006-01-02.log" layout.
+// Archives nested in archives are regular files.
+func WithArchives() FileOption {
+	return func(c *fileConfig) { c.archives = true }
+}
+
+// WithErrorPolicy opts to handle children errors with the given policy (FileErrorsLog by default).
+// With WithLazyChildren, children are read after the constructor returns, so errors can't be returned:
+// FileErrorsHalt stops reading the directory and FileErrorsCollect logs them.
+func WithErrorPolicy(policy FileErrorPolicy) FileOption {
+	return func(c *fileConfig) { c.errorPolicy = policy }
+}
+
+// WithErrorHandler opts to pass children errors to the given handler (instead of applying the error policy).
+// If the handler returns nil, the failed child is skipped, otherwise building is halted with the returned error.
+func WithErrorHandler(handler func(path string, err error) error) FileOption {
+	return func(c *fileConfig) { c.errorHandler = handler }
+}
+
+// WithLogger opts to log errors to the given logger instead of the default one (slog.Default).
+func WithLogger(logger *slog.Logger) FileOption {
+	return func(c *fileConfig) { c.logger = logger }
+}
+
+// haltError marks an error that halts building the tree, so it's passed up as is
+// instead of being handled again by every parent.
+type haltError struct{ err error }
+
+func (e *haltError) Error() string { return e.err.Error() }
+func (e *haltError) Unwrap() error { return e.err }
+
+// unwrapHalt returns the original error of haltError (if it's the one).
+func unwrapHalt(err error) error {
+	var halt *haltError
+	if errors.As(err, &halt) {
+		return halt.err
+	}
+	return err
+}
+
+// handleError handles an error met for the given path according to the config.
+// It returns non-nil error if building is to be halted.
+func (c *fileConfig) handleError(path string, err error) error {
+	var halt *haltError
+	if errors.As(err, &halt) {
+		return err
+	}
+
+	if c.errorHandler != nil {
+		if err := c.errorHandler(path, err); err != nil {
+			return &haltError{err: err}
+		}
+		return nil
+	}
+
+	switch c.errorPolicy {
+	case FileErrorsHalt:
+		return &haltError{err: err}
+	case FileErrorsCollect:
+		if !c.lazy {
+			c.errsMu.Lock()
+			c.errs = append(c.errs, err)
+			c.errsMu.Unlock()
+			return nil
+		}
+		fallthrough
+	case FileErrorsLog:
+		c.log(fmt.Sprintf("child: %s failed: %s", path, err))
+		return nil
+	default:
+		panic("invalid file error policy: " + c.errorPolicy)
+	}
+}
+
+// collectedErrors returns errors collected with FileErrorsCollect policy joined (nil if there are none).
+func (c *fileConfig) collectedErrors() error {
+	c.errsMu.Lock()
+	defer c.errsMu.Unlock()
+	return errors.Join(c.errs...)
+}
+
+func (c *fileConfig) log(msg string) {
+	logger := c.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger.Info(msg)
+}
+
+// withFS sets the file system of waypoints to the given fs.FS (see NewWaypointFileFS).
+func withFS(fsys fs.FS) FileOption {
+	return func(c *fileConfig) { c.fs = ioFileSystem{fsys: fsys} }
+}
@@ -0,0 +1,130 @@
+package years
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SymlinkPolicy specifies how symbolic links are handled while scanning directories.
+type SymlinkPolicy string
+
+const (
+	// SymlinksFollow follows symbolic links (the default).
+	// Note: links pointing to a parent directory make the tree infinite (bounded by MaxDepth only).
+	SymlinksFollow SymlinkPolicy = "follow"
+	// SymlinksSkip skips symbolic links.
+	SymlinksSkip SymlinkPolicy = "skip"
+	// SymlinksDetectCycles follows symbolic links except the ones pointing to the directory being scanned,
+	// to any of its parents, or to any directory already entered on the way to it (e.g. via another link).
+	SymlinksDetectCycles SymlinkPolicy = "detect_cycles"
+)
+
+// ScanOptions holds filtering options for scanning directories of file waypoints.
+// Zero value means no filtering except skipping hidden files (same as filepath.Glob does).
+type ScanOptions struct {
+	// Include are glob patterns (see filepath.Match) for names of files to be included.
+	// If set, a file must match any of them. Directories are not checked, so they are still scanned.
+	Include []string
+
+	// Exclude are glob patterns (see filepath.Match) for names of files and directories to be skipped.
+	Exclude []string
+
+	// IncludeHidden opts to include hidden files and directories (the ones whose names start with a dot).
+	IncludeHidden bool
+
+	// MaxDepth limits the depth of scanning: 1 means only the root's children, etc. 0 means no limit.
+	MaxDepth int
+
+	// Symlinks is the policy for symbolic links (SymlinksFollow by default).
+	Symlinks SymlinkPolicy
+
+	// MinTime and MaxTime skip files whose time is out of [MinTime, MaxTime] (zero values mean no bound).
+	// Files having no time (non calendar ones) are skipped as well if any bound is set.
+	// Directories are not checked, as they usually contain files of a wider period than their own time.
+	MinTime time.Time
+	MaxTime time.Time
+}
+
+// acceptsEntry checks if a directory entry passes name-based filters.
+func (so *ScanOptions) acceptsEntry(entry fs.DirEntry) bool {
+	name := entry.Name()
+	if !so.IncludeHidden && strings.HasPrefix(name, ".") {
+		return false
+	}
+
+	matches := func(pattern string) bool {
+		matched, err := filepath.Match(pattern, name)
+		return err == nil && matched
+	}
+	if slices.ContainsFunc(so.Exclude, matches) {
+		return false
+	}
+
+	// symlinks are not known to be dirs or files here, so they are checked via acceptsWaypoint
+	if len(so.Include) > 0 && entry.Type().IsRegular() && !slices.ContainsFunc(so.Include, matches) {
+		return false
+	}
+
+	return true
+}
+
+// acceptsSymlink checks if a symbolic link found in the given directory is to be followed.
+// descent are real paths of the directory and its ancestors in the scanned tree (see SymlinksDetectCycles).
+func (so *ScanOptions) acceptsSymlink(fsys fileSystem, descent []string, dirPath, linkPath string) bool {
+	switch so.Symlinks {
+	case SymlinksSkip:
+		return false
+	case SymlinksDetectCycles:
+		target, err := fsys.EvalSymlinks(linkPath)
+		if err != nil {
+			// dangling links are passed further to be reported as errors
+			return true
+		}
+		dir, err := fsys.EvalSymlinks(dirPath)
+		if err != nil {
+			return true
+		}
+
+		isAncestor := dir == target || target == "." ||
+			strings.HasPrefix(dir, target+string(os.PathSeparator)) || strings.HasPrefix(dir, target+"/")
+		// links may lead back via other links too, e.g. "a/l1 -> ../b" and "b/l2 -> ../a"
+		isVisited := slices.Contains(descent, target)
+		return !isAncestor && !isVisited
+	case SymlinksFollow, "":
+		return true
+	default:
+		panic("invalid symlink policy: " + so.Symlinks)
+	}
+}
+
+// acceptsWaypoint checks if a created child waypoint passes the filters.
+func (so *ScanOptions) acceptsWaypoint(w Waypoint) bool {
+	if w.IsContainer() {
+		return true
+	}
+
+	if len(so.Include) > 0 {
+		name := filepath.Base(w.Identifier())
+		if !slices.ContainsFunc(so.Include, func(pattern string) bool {
+			matched, err := filepath.Match(pattern, name)
+			return err == nil && matched
+		}) {
+			return false
+		}
+	}
+
+	if so.MinTime.IsZero() && so.MaxTime.IsZero() {
+		return true
+	}
+
+	t := w.Time()
+	if t.IsZero() {
+		return false
+	}
+
+	return (so.MinTime.IsZero() || !t.Before(so.MinTime)) && (so.MaxTime.IsZero() || !t.After(so.MaxTime))
+}
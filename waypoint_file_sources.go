@@ -0,0 +1,167 @@
+package years
+
+import (
+	"io"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/djherbis/times"
+)
+
+// SourceFile is a file which time is being told by a TimeSource.
+type SourceFile struct {
+	// Path is the path of the file (as it's passed to the constructor + path inside).
+	Path string
+	// RelPath is the path of the file relative to the root waypoint ("." for the root itself).
+	RelPath string
+	// Info is the file info.
+	Info fs.FileInfo
+	// Timespec holds file times (see NewWaypointFileFS for file systems not providing them).
+	Timespec times.Timespec
+
+	fs fileSystem
+}
+
+// Open opens the file for reading its contents.
+func (f SourceFile) Open() (io.ReadCloser, error) { return f.fs.Open(f.Path) }
+
+// TimeSource tells the time of a file (e.g. from its name, contents or file times).
+// Sources are chained in NewWaypointFileWithSources: the first one that can tell the time wins.
+type TimeSource interface {
+	// Name identifies the source, e.g. for auditing where waypoint's time came from.
+	Name() string
+
+	// Time returns the time of the given file, or false if the source can't tell it.
+	Time(file SourceFile) (time.Time, bool)
+}
+
+// timeSource is a TimeSource made of a function.
+type timeSource struct {
+	name string
+	fn   func(file SourceFile) (time.Time, bool)
+}
+
+func (s timeSource) Name() string                           { return s.name }
+func (s timeSource) Time(file SourceFile) (time.Time, bool) { return s.fn(file) }
+
+// NewTimeSource creates a TimeSource with the given name from the given function.
+func NewTimeSource(name string, fn func(file SourceFile) (time.Time, bool)) TimeSource {
+	return timeSource{name: name, fn: fn}
+}
+
+// Names of built-in time sources.
+const (
+	TimeSourceLayout            = "layout"
+	TimeSourceEmbeddedTimestamp = "embedded_timestamp"
+	TimeSourceFrontMatter       = "front_matter"
+	TimeSourceContent           = "content"
+	TimeSourceBirthTime         = "birth_time"
+	TimeSourceModTime           = "mod_time"
+)
+
+// DefaultTimeSources is the chain of: name layout, embedded timestamp, front-matter date, birth time, mod time.
+// Files always get a time with it (mod time is always available).
+func DefaultTimeSources(fullLayout string) []TimeSource {
+	return []TimeSource{
+		TimeFromLayout(fullLayout),
+		TimeFromEmbeddedTimestamp(),
+		TimeFromFrontMatter(),
+		TimeFromBirthTime(),
+		TimeFromModTime(),
+	}
+}
+
+// TimeFromLayout tells the time from the relative path of the file via the given full layout,
+// the same way as NewTimeNamedWaypointFile does: e.g. "2024/Mar/2024-03-05.txt" for "2006/Jan/2006-01-02.txt".
+// Path elements are aligned to layout elements from the root, so "2024/Mar" is parsed via "2006/Jan".
+func TimeFromLayout(fullLayout string) TimeSource {
+	layoutParts := strings.Split(filepath.ToSlash(fullLayout), "/")
+
+	return NewTimeSource(TimeSourceLayout, func(file SourceFile) (time.Time, bool) {
+		if file.RelPath == "" || file.RelPath == "." {
+			return time.Time{}, false
+		}
+
+		pathParts := strings.Split(filepath.ToSlash(file.RelPath), "/")
+		if len(pathParts) > len(layoutParts) {
+			return time.Time{}, false
+		}
+
+		// Default parser is used. Use years.SetParserDefaults to configure parsing
+		layout := strings.Join(layoutParts[:len(pathParts)], "/")
+		t, err := NewParser().Parse(layout, strings.Join(pathParts, "/"))
+		if err != nil {
+			return time.Time{}, false
+		}
+
+		return t, true
+	})
+}
+
+// embeddedDateRegex matches dates like 2024-03-05 or 20240305, optionally followed by time
+// like T10:11:12, _10-11-12 or 101112.
+var embeddedDateRegex = regexp.MustCompile(
+	`(?:^|\D)(\d{4})-?(\d{2})-?(\d{2})(?:[T_ -]?(\d{2})[:.-]?(\d{2})[:.-]?(\d{2}))?(?:\D|$)`,
+)
+
+// embeddedUnixRegex matches unix timestamps in seconds (10 digits are years 2001-2286).
+var embeddedUnixRegex = regexp.MustCompile(`(?:^|\D)(\d{10})(?:\D|$)`)
+
+// TimeFromEmbeddedTimestamp tells the time from a timestamp embedded in the file name,
+// e.g. "backup-2024-03-05.tar", "IMG_20240305_101112.jpg" or "dump-1709633472.sql".
+// The first valid timestamp found in the name is used.
+func TimeFromEmbeddedTimestamp() TimeSource {
+	return NewTimeSource(TimeSourceEmbeddedTimestamp, func(file SourceFile) (time.Time, bool) {
+		return parseEmbeddedTimestamp(file.Info.Name())
+	})
+}
+
+// parseEmbeddedTimestamp finds the first valid timestamp in the given string.
+func parseEmbeddedTimestamp(s string) (time.Time, bool) {
+	parser := NewParser()
+	for _, m := range embeddedDateRegex.FindAllStringSubmatch(s, -1) {
+		value, layout := m[1]+"-"+m[2]+"-"+m[3], LayoutDate
+		if m[4] != "" {
+			value, layout = value+" "+m[4]+":"+m[5]+":"+m[6], LayoutDateTime
+		}
+
+		if t, err := parser.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+
+	for _, m := range embeddedUnixRegex.FindAllStringSubmatch(s, -1) {
+		if secs, err := strconv.ParseInt(m[1], 10, 64); err == nil {
+			return time.Unix(secs, 0).UTC(), true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// TimeFromFrontMatter tells the time from the front-matter of the file (see FrontMatterDate).
+// Only the first 4KB of the file are read.
+func TimeFromFrontMatter(keys ...string) TimeSource {
+	return newContentTimeSource(TimeSourceFrontMatter, FrontMatterDate(keys...), 0)
+}
+
+// TimeFromBirthTime tells the birth (creation) time of the file if it's available.
+func TimeFromBirthTime() TimeSource {
+	return NewTimeSource(TimeSourceBirthTime, func(file SourceFile) (time.Time, bool) {
+		if file.Timespec == nil || !file.Timespec.HasBirthTime() {
+			return time.Time{}, false
+		}
+		return file.Timespec.BirthTime(), true
+	})
+}
+
+// TimeFromModTime tells the modification time of the file.
+func TimeFromModTime() TimeSource {
+	return NewTimeSource(TimeSourceModTime, func(file SourceFile) (time.Time, bool) {
+		return file.Info.ModTime(), true
+	})
+}
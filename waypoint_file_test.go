@@ -0,0 +1,511 @@
+package years_test
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/amberpixels/years"
+	"github.com/djherbis/times"
+	"github.com/expectto/be"
+)
+
+// writeFiles creates given files (with parent directories) under root.
+func writeFiles(t *testing.T, root string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		path := filepath.Join(root, name)
+		be.Require(t, os.MkdirAll(filepath.Dir(path), 0o755)).To(be.Succeed())
+		be.Require(t, os.WriteFile(path, []byte(name), 0o600)).To(be.Succeed())
+	}
+}
+
+// sorted returns the given names sorted (children are sorted by time, which may be equal for fresh files).
+func sorted(names []string) []string {
+	slices.Sort(names)
+	return names
+}
+
+// childrenNames returns base names of waypoint's children.
+func childrenNames(w years.Waypoint) []string {
+	names := make([]string, 0)
+	for _, child := range w.Children() {
+		names = append(names, filepath.Base(child.Identifier()))
+	}
+	return names
+}
+
+func TestWaypointFile_LazyChildren(t *testing.T) {
+	voyagerSetup(t)
+	root := t.TempDir()
+	writeFiles(t, root, "a.txt")
+
+	modTime := func(ts times.Timespec) time.Time { return ts.ModTime() }
+
+	t.Run("children are read on first access and cached", func(t *testing.T) {
+		wf, err := years.NewWaypointFile(root, modTime, years.WithLazyChildren())
+		be.Require(t, err).To(be.Succeed())
+
+		// created after construction, but before the first access
+		writeFiles(t, root, "b.txt")
+		be.Expect(t, childrenNames(wf)).To(be.Eq([]string{"a.txt", "b.txt"}))
+
+		writeFiles(t, root, "c.txt")
+		be.Expect(t, childrenNames(wf)).To(be.Eq([]string{"a.txt", "b.txt"}))
+	})
+
+	t.Run("without cache children are re-read", func(t *testing.T) {
+		wf, err := years.NewWaypointFile(root, modTime, years.WithLazyChildren(), years.WithoutChildrenCache())
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, childrenNames(wf)).To(be.HaveLength(3))
+
+		writeFiles(t, root, "d.txt")
+		be.Expect(t, childrenNames(wf)).To(be.HaveLength(4))
+	})
+}
+
+func TestTimeNamedWaypointFile_Parent(t *testing.T) {
+	const testCalendarLayout = "2006/Jan/2006-01-02.txt"
+	root := filepath.Join(t.TempDir(), "calendar")
+	writeFiles(t, root, "2024/Mar/2024-03-05.txt")
+
+	year, err := years.NewTimeNamedWaypointFile(filepath.Join(root, "2024"), testCalendarLayout)
+	be.Require(t, err).To(be.Succeed())
+	be.Expect(t, year.Time().Equal(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))).To(be.True())
+
+	// month's layout and time input are inherited from the year
+	month, err := years.NewTimeNamedWaypointFile(filepath.Join(root, "2024", "Mar"), testCalendarLayout, year)
+	be.Require(t, err).To(be.Succeed())
+	be.Expect(t, month.Time().Equal(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC))).To(be.True())
+	be.Require(t, month.Children()).To(be.HaveLength(1))
+	be.Expect(t, month.Children()[0].Time().Equal(time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC))).To(be.True())
+}
+
+func TestTimeNamedWaypointFile_LazyChildren(t *testing.T) {
+	const testCalendarLayout = "2006/Jan/2006-01-02.txt"
+	voyagerSetup(t, "2006", "Jan", "2006-01-02")
+	// root's name is parsed as well, so it must not look like a time (t.TempDir() is numeric)
+	root := filepath.Join(t.TempDir(), "calendar")
+	writeFiles(t, root, "2024/Mar/2024-03-05.txt")
+
+	wf, err := years.NewTimeNamedWaypointFileWithOptions(root, testCalendarLayout, years.WithLazyChildren())
+	be.Require(t, err).To(be.Succeed())
+
+	writeFiles(t, root, "2024/Mar/2024-03-06.txt")
+
+	navigated, err := years.NewVoyager(wf).Navigate("2024-03-06")
+	be.Require(t, err).To(be.Succeed())
+	be.Require(t, navigated).NotTo(be.Nil())
+	be.Expect(t, navigated.Identifier()).To(be.Eq(filepath.Join(root, "2024", "Mar", "2024-03-06.txt")))
+}
+
+func TestTimeNamedWaypointFile_PrunedNavigation(t *testing.T) {
+	const testCalendarLayout = "2006/Jan/2006-01-02.txt"
+	voyagerSetup(t, "2006", "Jan", "2006-01-02")
+	root := filepath.Join(t.TempDir(), "calendar")
+	writeFiles(t, root,
+		"2023/Dec/2023-12-31.txt",
+		"2024/Feb/2024-02-01.txt",
+		"2024/Mar/2024-03-05.txt",
+		"2024/Mar/2024-03-06.txt",
+	)
+
+	wf, err := years.NewTimeNamedWaypointFileWithOptions(root, testCalendarLayout, years.WithLazyChildren())
+	be.Require(t, err).To(be.Succeed())
+
+	navigated, err := years.NewVoyager(wf).Navigate("2024-03-05")
+	be.Require(t, err).To(be.Succeed())
+	be.Require(t, navigated).NotTo(be.Nil())
+	be.Expect(t, navigated.Identifier()).To(be.Eq(filepath.Join(root, "2024", "Mar", "2024-03-05.txt")))
+
+	// only containers on the way to the target were read
+	loaded := make([]string, 0)
+	var walk func(w *years.TimeNamedWaypointFile)
+	walk = func(w *years.TimeNamedWaypointFile) {
+		if !w.IsContainer() || !years.IsChildrenLoaded(w.WaypointFile) {
+			return
+		}
+		loaded = append(loaded, filepath.Base(w.Identifier()))
+		for _, child := range w.Children() {
+			walk(child.(*years.TimeNamedWaypointFile))
+		}
+	}
+	walk(wf)
+	be.Expect(t, loaded).To(be.Eq([]string{"calendar", "2024", "Mar"}))
+}
+
+func TestWaypointFile_ErrorHandling(t *testing.T) {
+	voyagerSetup(t)
+	root := t.TempDir()
+	writeFiles(t, root, "a.txt", "inner/b.txt")
+	// dangling symlinks can't be stat-ed
+	be.Require(t, os.Symlink(filepath.Join(root, "missing"), filepath.Join(root, "broken"))).To(be.Succeed())
+	be.Require(t, os.Symlink(filepath.Join(root, "missing"), filepath.Join(root, "inner", "broken"))).To(be.Succeed())
+
+	modTime := func(ts times.Timespec) time.Time { return ts.ModTime() }
+
+	t.Run("log to the given logger", func(t *testing.T) {
+		var logs bytes.Buffer
+		wf, err := years.NewWaypointFile(root, modTime, years.WithLogger(slog.New(slog.NewTextHandler(&logs, nil))))
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, childrenNames(wf)).To(be.Eq([]string{"a.txt", "inner"}))
+		be.Expect(t, strings.Count(logs.String(), "\n")).To(be.Eq(2))
+	})
+
+	t.Run("halt", func(t *testing.T) {
+		wf, err := years.NewWaypointFile(root, modTime, years.WithErrorPolicy(years.FileErrorsHalt))
+		be.Expect(t, err).To(be.HaveOccurred())
+		be.Expect(t, errors.Is(err, fs.ErrNotExist)).To(be.True())
+		be.Expect(t, wf).To(be.Nil())
+	})
+
+	t.Run("collect", func(t *testing.T) {
+		wf, err := years.NewTimeNamedWaypointFileWithOptions(root, "2006-01-02.txt", years.WithErrorPolicy(years.FileErrorsCollect))
+		be.Require(t, wf).NotTo(be.Nil())
+		be.Expect(t, childrenNames(wf)).To(be.Eq([]string{"a.txt", "inner"}))
+
+		var joined interface{ Unwrap() []error }
+		be.Require(t, errors.As(err, &joined)).To(be.True())
+		be.Expect(t, joined.Unwrap()).To(be.HaveLength(2))
+	})
+
+	t.Run("custom handler", func(t *testing.T) {
+		failed := make([]string, 0)
+		errStop := errors.New("stop")
+		_, err := years.NewWaypointFile(root, modTime, years.WithErrorHandler(func(path string, err error) error {
+			failed = append(failed, strings.TrimPrefix(path, root))
+			if strings.Contains(path, "inner") {
+				return errStop
+			}
+			return nil
+		}))
+		be.Expect(t, errors.Is(err, errStop)).To(be.True())
+		// the halting error is not passed to the handler again by parents
+		be.Expect(t, failed).To(be.Eq([]string{"/broken", "/inner/broken"}))
+	})
+}
+
+func TestWaypointFile_ScanOptions(t *testing.T) {
+	voyagerSetup(t)
+	root := t.TempDir()
+	writeFiles(t, root, "a.txt", "b.md", ".hidden", "inner/c.txt", "inner/deep/d.txt", "skip/e.txt")
+
+	modTime := func(ts times.Timespec) time.Time { return ts.ModTime() }
+	identifiers := func(w years.Waypoint) []string {
+		result := make([]string, 0)
+		for wp := range years.NewVoyager(w).All(years.O_ALL()) {
+			if wp != w {
+				result = append(result, strings.TrimPrefix(wp.Identifier(), root+string(filepath.Separator)))
+			}
+		}
+		slices.Sort(result)
+		return result
+	}
+
+	t.Run("hidden files are skipped by default", func(t *testing.T) {
+		wf, err := years.NewWaypointFile(root, modTime)
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, slices.Contains(childrenNames(wf), ".hidden")).To(be.False())
+
+		wf, err = years.NewWaypointFile(root, modTime, years.WithScanOptions(years.ScanOptions{IncludeHidden: true}))
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, slices.Contains(childrenNames(wf), ".hidden")).To(be.True())
+	})
+
+	t.Run("include and exclude globs", func(t *testing.T) {
+		wf, err := years.NewWaypointFile(root, modTime, years.WithScanOptions(years.ScanOptions{
+			Include: []string{"*.txt"},
+			Exclude: []string{"skip", "deep"},
+		}))
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, identifiers(wf)).To(be.Eq([]string{"a.txt", "inner", filepath.Join("inner", "c.txt")}))
+	})
+
+	t.Run("max depth", func(t *testing.T) {
+		wf, err := years.NewWaypointFile(root, modTime, years.WithScanOptions(years.ScanOptions{MaxDepth: 1}))
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, identifiers(wf)).To(be.Eq([]string{"a.txt", "b.md", "inner", "skip"}))
+
+		lazy, err := years.NewWaypointFile(root, modTime,
+			years.WithLazyChildren(), years.WithScanOptions(years.ScanOptions{MaxDepth: 2}),
+		)
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, identifiers(lazy)).To(be.Eq([]string{
+			"a.txt", "b.md", "inner",
+			filepath.Join("inner", "c.txt"), filepath.Join("inner", "deep"),
+			"skip", filepath.Join("skip", "e.txt"),
+		}))
+	})
+
+	t.Run("symlinks", func(t *testing.T) {
+		linked := t.TempDir()
+		writeFiles(t, linked, "a.txt", "inner/b.txt")
+		be.Require(t, os.Symlink(linked, filepath.Join(linked, "inner", "loop"))).To(be.Succeed())
+		be.Require(t, os.Symlink(filepath.Join(linked, "a.txt"), filepath.Join(linked, "link.txt"))).To(be.Succeed())
+
+		wf, err := years.NewWaypointFile(linked, modTime,
+			years.WithScanOptions(years.ScanOptions{Symlinks: years.SymlinksSkip}),
+		)
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, sorted(childrenNames(wf))).To(be.Eq([]string{"a.txt", "inner"}))
+
+		wf, err = years.NewWaypointFile(linked, modTime,
+			years.WithScanOptions(years.ScanOptions{Symlinks: years.SymlinksDetectCycles}),
+		)
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, sorted(childrenNames(wf))).To(be.Eq([]string{"a.txt", "inner", "link.txt"}))
+		for _, child := range wf.Children() {
+			if child.IsContainer() {
+				be.Expect(t, childrenNames(child)).To(be.Eq([]string{"b.txt"}))
+			}
+		}
+	})
+
+	t.Run("symlinks between siblings", func(t *testing.T) {
+		siblings := t.TempDir()
+		writeFiles(t, siblings, "a/a.txt", "b/b.txt")
+		be.Require(t, os.Symlink(filepath.Join("..", "b"), filepath.Join(siblings, "a", "l1"))).To(be.Succeed())
+		be.Require(t, os.Symlink(filepath.Join("..", "a"), filepath.Join(siblings, "b", "l2"))).To(be.Succeed())
+
+		// MaxDepth makes a missed cycle fail instead of hanging
+		wf, err := years.NewWaypointFile(siblings, modTime,
+			years.WithScanOptions(years.ScanOptions{Symlinks: years.SymlinksDetectCycles, MaxDepth: 20}),
+		)
+		be.Require(t, err).To(be.Succeed())
+
+		paths := collectTraverse(t, years.NewVoyager(wf), years.O_NON_CALENDAR())
+		relPaths := make([]string, 0, len(paths))
+		for _, path := range paths {
+			rel, err := filepath.Rel(siblings, path)
+			be.Require(t, err).To(be.Succeed())
+			relPaths = append(relPaths, rel)
+		}
+		be.Expect(t, sorted(relPaths)).To(be.Eq(sorted([]string{
+			".", "a", "b",
+			filepath.Join("a", "a.txt"), filepath.Join("a", "l1"), filepath.Join("a", "l1", "b.txt"),
+			filepath.Join("b", "b.txt"), filepath.Join("b", "l2"), filepath.Join("b", "l2", "a.txt"),
+		})))
+	})
+}
+
+func TestTimeNamedWaypointFile_ScanTimeBounds(t *testing.T) {
+	const testCalendarLayout = "2006/Jan/2006-01-02.txt"
+	voyagerSetup(t, "2006", "Jan", "2006-01-02")
+	root := filepath.Join(t.TempDir(), "calendar")
+	writeFiles(t, root,
+		"2024/Jan/2024-01-31.txt",
+		"2024/Feb/2024-02-01.txt",
+		"2024/Feb/2024-02-10.txt",
+		"2024/Mar/2024-03-01.txt",
+		"2024/Mar/notes.txt",
+	)
+
+	wf, err := years.NewTimeNamedWaypointFileWithOptions(root, testCalendarLayout, years.WithScanOptions(years.ScanOptions{
+		MinTime: time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+		MaxTime: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+	}))
+	be.Require(t, err).To(be.Succeed())
+
+	leaves := make([]string, 0)
+	for w := range years.NewVoyager(wf).All(years.O_FUTURE(), years.O_LEAVES_ONLY()) {
+		leaves = append(leaves, filepath.Base(w.Identifier()))
+	}
+	be.Expect(t, leaves).To(be.Eq([]string{"2024-02-01.txt", "2024-02-10.txt"}))
+}
+
+func TestWaypointFile_FS(t *testing.T) {
+	const testCalendarLayout = "2006/Jan/2006-01-02.txt"
+	voyagerSetup(t, "2006", "Jan", "2006-01-02")
+
+	modTime := func(day int) time.Time { return time.Date(2024, time.March, day, 12, 0, 0, 0, time.UTC) }
+	fsys := fstest.MapFS{
+		"calendar/2024/Mar/2024-03-05.txt": {Data: []byte("5"), ModTime: modTime(6)},
+		"calendar/2024/Mar/2024-03-04.txt": {Data: []byte("4"), ModTime: modTime(7)},
+		"calendar/2024/Mar/notes.txt":      {Data: []byte("notes"), ModTime: modTime(1)},
+	}
+
+	t.Run("times fall back to the modification time", func(t *testing.T) {
+		wf, err := years.NewWaypointFileFS(fsys, "calendar/2024/Mar", func(ts times.Timespec) time.Time {
+			return ts.BirthTime()
+		})
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, childrenNames(wf)).To(be.Eq([]string{"notes.txt", "2024-03-05.txt", "2024-03-04.txt"}))
+		be.Expect(t, wf.Children()[0].Time()).To(be.Eq(modTime(1)))
+		be.Expect(t, wf.Children()[0].Identifier()).To(be.Eq("calendar/2024/Mar/notes.txt"))
+	})
+
+	t.Run("time named", func(t *testing.T) {
+		wf, err := years.NewTimeNamedWaypointFileFS(fsys, "calendar", testCalendarLayout, years.WithLazyChildren())
+		be.Require(t, err).To(be.Succeed())
+
+		leaves := make([]string, 0)
+		for w := range years.NewVoyager(wf).All(years.O_FUTURE(), years.O_LEAVES_ONLY()) {
+			leaves = append(leaves, w.Identifier())
+		}
+		be.Expect(t, leaves).To(be.Eq([]string{"calendar/2024/Mar/2024-03-04.txt", "calendar/2024/Mar/2024-03-05.txt"}))
+	})
+
+	t.Run("os.DirFS keeps OS file times", func(t *testing.T) {
+		root := t.TempDir()
+		writeFiles(t, root, "a.txt")
+
+		wf, err := years.NewWaypointFileFS(os.DirFS(root), ".", func(ts times.Timespec) time.Time {
+			return ts.AccessTime()
+		})
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, childrenNames(wf)).To(be.Eq([]string{"a.txt"}))
+
+		ts, err := times.Stat(filepath.Join(root, "a.txt"))
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, wf.Children()[0].Time().Equal(ts.AccessTime())).To(be.True())
+	})
+}
+
+func TestWaypointFile_TimeSources(t *testing.T) {
+	voyagerSetup(t, "2006", "Jan", "2006-01-02")
+	root := filepath.Join(t.TempDir(), "calendar")
+	writeFiles(t, root,
+		"2024/Mar/2024-03-05.txt",
+		"2024/Mar/backup_20240306_101112.tar",
+		"2024/Mar/dump-1709856000.sql",
+	)
+	be.Require(t, os.WriteFile(filepath.Join(root, "2024", "Mar", "note.md"),
+		[]byte("---\ntitle: Note\ndate: \"2024-03-09\"\n---\nbody with 2020-01-01\n"), 0o600),
+	).To(be.Succeed())
+	writeFiles(t, root, "2024/Mar/plain.txt")
+
+	wf, err := years.NewWaypointFileWithSources(root, years.DefaultTimeSources("2006/Jan/2006-01-02.txt"))
+	be.Require(t, err).To(be.Succeed())
+
+	sources := make(map[string]string)
+	timesByName := make(map[string]time.Time)
+	for w := range years.NewVoyager(wf).All(years.O_ALL(), years.O_NON_CALENDAR()) {
+		wp := w.(*years.WaypointFile)
+		be.Require(t, wp.TimeSource()).NotTo(be.Nil())
+		sources[filepath.Base(wp.Identifier())] = wp.TimeSource().Name()
+		timesByName[filepath.Base(wp.Identifier())] = wp.Time()
+	}
+
+	// birth time is not available on every platform, so file times are taken from mod time then
+	fileTimeSource := years.TimeSourceModTime
+	if ts, err := times.Stat(root); err == nil && ts.HasBirthTime() {
+		fileTimeSource = years.TimeSourceBirthTime
+	}
+	be.Expect(t, sources).To(be.Eq(map[string]string{
+		"calendar":                   fileTimeSource,
+		"2024":                       years.TimeSourceLayout,
+		"Mar":                        years.TimeSourceLayout,
+		"2024-03-05.txt":             years.TimeSourceLayout,
+		"backup_20240306_101112.tar": years.TimeSourceEmbeddedTimestamp,
+		"dump-1709856000.sql":        years.TimeSourceEmbeddedTimestamp,
+		"note.md":                    years.TimeSourceFrontMatter,
+		"plain.txt":                  fileTimeSource,
+	}))
+
+	be.Expect(t, timesByName["Mar"]).To(be.Eq(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)))
+	be.Expect(t, timesByName["backup_20240306_101112.tar"]).To(be.Eq(time.Date(2024, time.March, 6, 10, 11, 12, 0, time.UTC)))
+	be.Expect(t, timesByName["dump-1709856000.sql"]).To(be.Eq(time.Date(2024, time.March, 8, 0, 0, 0, 0, time.UTC)))
+	be.Expect(t, timesByName["note.md"]).To(be.Eq(time.Date(2024, time.March, 9, 0, 0, 0, 0, time.UTC)))
+
+	t.Run("custom source", func(t *testing.T) {
+		fixed := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+		wf, err := years.NewWaypointFileWithSources(root, []years.TimeSource{
+			years.TimeFromLayout("2006/Jan/2006-01-02.txt"),
+			years.NewTimeSource("fixed", func(file years.SourceFile) (time.Time, bool) {
+				return fixed, file.Info.IsDir()
+			}),
+		})
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, wf.TimeSource().Name()).To(be.Eq("fixed"))
+		be.Expect(t, wf.Time()).To(be.Eq(fixed))
+
+		for _, leaf := range wf.Children()[0].Children()[0].Children() {
+			if filepath.Base(leaf.Identifier()) != "2024-03-05.txt" {
+				be.Expect(t, leaf.(*years.WaypointFile).TimeSource()).To(be.Nil())
+				be.Expect(t, leaf.Time().IsZero()).To(be.True())
+			}
+		}
+	})
+}
+
+func TestWaypointFile_Content(t *testing.T) {
+	voyagerSetup(t)
+	root := filepath.Join(t.TempDir(), "notes")
+	contents := map[string]string{
+		"yaml.md":  "---\ntitle: \"Note\"\ndate: 2024-03-05\n---\n2020-01-01 is not the date\n",
+		"toml.md":  "+++\ndate = \"2024-03-06T10:00:00Z\"\n+++\n",
+		"plain.md": "no front-matter\ndate: 2024-03-07\n",
+		"app.log":  "\n[2024-03-08 10:11:12] INFO started\n2024-03-09 00:00:00 INFO stopped\n",
+		"now.log":  "now starting\n",
+		"unix.log": "1709632800 INFO started\n",
+	}
+	for name, content := range contents {
+		be.Require(t, os.MkdirAll(root, 0o755)).To(be.Succeed())
+		be.Require(t, os.WriteFile(filepath.Join(root, name), []byte(content), 0o600)).To(be.Succeed())
+	}
+
+	timesByName := func(w years.Waypoint) map[string]time.Time {
+		result := make(map[string]time.Time)
+		for _, child := range w.Children() {
+			if !child.Time().IsZero() {
+				result[filepath.Base(child.Identifier())] = child.Time()
+			}
+		}
+		return result
+	}
+
+	t.Run("front-matter", func(t *testing.T) {
+		wf, err := years.NewContentWaypointFile(root, years.FrontMatterDate())
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, wf.Time().IsZero()).To(be.True())
+		be.Expect(t, timesByName(wf)).To(be.Eq(map[string]time.Time{
+			"yaml.md": time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC),
+			"toml.md": time.Date(2024, time.March, 6, 10, 0, 0, 0, time.UTC),
+		}))
+	})
+
+	t.Run("first line timestamp", func(t *testing.T) {
+		wf, err := years.NewContentWaypointFile(root, years.FirstLineTimestamp())
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, timesByName(wf)).To(be.Eq(map[string]time.Time{
+			"app.log": time.Date(2024, time.March, 8, 10, 11, 12, 0, time.UTC),
+		}))
+	})
+
+	t.Run("first line unix timestamp", func(t *testing.T) {
+		wf, err := years.NewContentWaypointFile(root, years.FirstLineTimestamp(years.LayoutTimestampSeconds))
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, timesByName(wf)).To(be.Eq(map[string]time.Time{
+			"unix.log": time.Date(2024, time.March, 5, 10, 0, 0, 0, time.UTC),
+		}))
+	})
+
+	t.Run("custom extractor with a bounded prefix", func(t *testing.T) {
+		var maxRead int
+		extractor := func(content []byte, parser *years.Parser) (time.Time, bool) {
+			maxRead = max(maxRead, len(content))
+			t, err := parser.Parse("2006-01-02", strings.TrimPrefix(strings.Split(string(content), "\n")[1], "date: "))
+			return t, err == nil
+		}
+
+		wf, err := years.NewWaypointFileWithSources(root, []years.TimeSource{years.TimeFromContent(extractor, 40)})
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, maxRead).To(be.Eq(40))
+		be.Expect(t, timesByName(wf)).To(be.Eq(map[string]time.Time{
+			"plain.md": time.Date(2024, time.March, 7, 0, 0, 0, 0, time.UTC),
+		}))
+		for _, child := range wf.Children() {
+			if filepath.Base(child.Identifier()) == "plain.md" {
+				be.Expect(t, child.(*years.WaypointFile).TimeSource().Name()).To(be.Eq(years.TimeSourceContent))
+			}
+		}
+	})
+}
@@ -0,0 +1,177 @@
+package years
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// GitCommit is a commit of a git repository (see NewGitWaypoint).
+type GitCommit struct {
+	Hash      string
+	Tree      string
+	Parents   []string
+	Author    GitSignature
+	Committer GitSignature
+	Message   string
+}
+
+// Summary returns the first line of the commit message.
+func (c GitCommit) Summary() string {
+	summary, _, _ := strings.Cut(c.Message, "\n")
+	return summary
+}
+
+// parseGitCommit parses the content of a commit object.
+func parseGitCommit(hash string, data []byte) (GitCommit, error) {
+	commit := GitCommit{Hash: hash}
+
+	headers, message, _ := strings.Cut(string(data), "\n\n")
+	commit.Message = message
+
+	for _, line := range strings.Split(headers, "\n") {
+		key, value, _ := strings.Cut(line, " ")
+
+		var err error
+		switch key {
+		case "tree":
+			commit.Tree = value
+		case "parent":
+			commit.Parents = append(commit.Parents, value)
+		case "author":
+			commit.Author, err = parseGitSignature(value)
+		case "committer":
+			commit.Committer, err = parseGitSignature(value)
+		}
+		if err != nil {
+			return GitCommit{}, fmt.Errorf("invalid commit %s: %w", hash, err)
+		}
+	}
+
+	return commit, nil
+}
+
+// gitConfig holds configuration for NewGitWaypoint.
+type gitConfig struct {
+	committerTime bool
+	branches      []string
+	groupByBranch bool
+}
+
+// GitOption defines functional options for NewGitWaypoint.
+type GitOption func(*gitConfig)
+
+// WithGitCommitterTime opts to take the committer time of commits (the author time is taken by default).
+func WithGitCommitterTime() GitOption {
+	return func(c *gitConfig) { c.committerTime = true }
+}
+
+// WithGitBranches opts to read commits reachable from the given local branches only.
+// By default all local branches are read: the HEAD's branch first, then others alphabetically.
+func WithGitBranches(names ...string) GitOption {
+	return func(c *gitConfig) { c.branches = names }
+}
+
+// WithGitGroupByBranch opts to nest commits in groups (WaypointGroup) per branch, identified by branch names.
+// A commit reachable from several branches belongs to the first one of them (see WithGitBranches),
+// so e.g. the group of a feature branch holds commits not merged into the main branch yet.
+func WithGitGroupByBranch() GitOption {
+	return func(c *gitConfig) { c.groupByBranch = true }
+}
+
+// NewGitWaypoint creates a waypoint for the history of the local git repository at the given path
+// (a working tree or a bare repository). Commits are leaves having the author (or committer) time.
+// Commits are available via ValueOf[GitCommit] and are identified by their hashes.
+// The repository is read once (objects and packfiles are parsed directly, git isn't required).
+func NewGitWaypoint(path string, opts ...GitOption) (*WaypointGroup, error) {
+	config := gitConfig{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	repo, err := openGitRepo(path)
+	if err != nil {
+		return nil, err
+	}
+	defer repo.close()
+
+	tips, err := repo.branches()
+	if err != nil {
+		return nil, err
+	}
+
+	names := config.branches
+	if len(names) == 0 {
+		head, err := repo.head()
+		if err != nil {
+			return nil, err
+		}
+		for name := range tips {
+			names = append(names, name)
+		}
+		slices.SortFunc(names, func(a, b string) int {
+			switch {
+			case a == head:
+				return -1
+			case b == head:
+				return 1
+			default:
+				return strings.Compare(a, b)
+			}
+		})
+	}
+
+	root := &WaypointGroup{identifier: path}
+	seen := make(map[string]bool)
+	for _, name := range names {
+		tip, ok := tips[name]
+		if !ok {
+			return nil, fmt.Errorf("branch %q not found", name)
+		}
+
+		parent := root
+		if config.groupByBranch {
+			parent = &WaypointGroup{identifier: name}
+			root.Add(parent)
+		}
+
+		// walking the graph from the tip, skipping commits already taken by previous branches
+		pending := []string{tip}
+		for len(pending) > 0 {
+			hash := pending[len(pending)-1]
+			pending = pending[:len(pending)-1]
+			if seen[hash] {
+				continue
+			}
+			seen[hash] = true
+
+			commit, err := repo.readCommit(hash)
+			if err != nil {
+				return nil, err
+			}
+
+			t := commit.Author.When
+			if config.committerTime {
+				t = commit.Committer.When
+			}
+			parent.Add(NewWaypointOf(commit, hash, t))
+
+			pending = append(pending, commit.Parents...)
+		}
+	}
+
+	return root, nil
+}
+
+// readCommit reads the commit object of the given hash.
+func (r *gitRepo) readCommit(hash string) (GitCommit, error) {
+	objectType, data, err := r.readObject(hash)
+	if err != nil {
+		return GitCommit{}, err
+	}
+	if objectType != gitObjectCommit {
+		return GitCommit{}, fmt.Errorf("object %s is not a commit", hash)
+	}
+
+	return parseGitCommit(hash, data)
+}
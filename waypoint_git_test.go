@@ -0,0 +1,166 @@
+package years_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/amberpixels/years"
+	"github.com/expectto/be"
+)
+
+// Commits of the test repository (oldest first). Main branch merges the feature one.
+// Older commits are packed (mostly as deltas), newer ones are loose objects.
+const (
+	gitInitial  = "142fbb2250c7001afff53cee8cb7248fc0049749"
+	gitParser   = "84d53b5063e17332388f474fa38edc3b1df8a7ca"
+	gitFix      = "b7368f699ae56e6f1975f12425e8649fa78b1e3d"
+	gitFeature  = "cc3645b28aa768af0bb92f2c20fb2a1ce9ef446c"
+	gitContinue = "a03df5ac155c5e774a2e7fdbde73ae858e4f3a30"
+	gitMerge    = "0b55e22619a58ccc5b7f4fbc09d2e2d299cf774c"
+)
+
+var testGitRepoPath = filepath.Join(TestDataPath, "git_repo.git")
+
+func TestGitWaypoint(t *testing.T) {
+	voyagerSetup(t, time.RFC3339)
+
+	repo, err := years.NewGitWaypoint(testGitRepoPath)
+	be.Require(t, err).To(be.Succeed())
+	be.Expect(t, repo.Identifier()).To(be.Eq(testGitRepoPath))
+	be.Expect(t, repo.Children()).To(be.HaveLength(6))
+
+	v := years.NewVoyager(repo)
+	be.Expect(t, collectTraverse(t, v, years.O_FUTURE(), years.O_LEAVES_ONLY())).To(be.Eq([]string{
+		gitInitial, gitParser, gitFix, gitFeature, gitContinue, gitMerge,
+	}))
+
+	t.Run("commits", func(t *testing.T) {
+		var merge years.GitCommit
+		for _, w := range repo.Children() {
+			if w.Identifier() == gitMerge {
+				merge, _ = years.ValueOf[years.GitCommit](w)
+				be.Expect(t, w.Time().Equal(time.Date(2024, time.March, 7, 15, 0, 0, 0, time.UTC))).To(be.True())
+			}
+		}
+
+		be.Expect(t, merge.Hash).To(be.Eq(gitMerge))
+		be.Expect(t, merge.Parents).To(be.Eq([]string{gitFix, gitContinue}))
+		be.Expect(t, merge.Author.Name).To(be.Eq("Bob"))
+		be.Expect(t, merge.Author.Email).To(be.Eq("bob@example.com"))
+		be.Expect(t, merge.Summary()).To(be.Eq("Merge feature"))
+
+		_, offset := merge.Author.When.Zone()
+		be.Expect(t, offset).To(be.Eq(-5 * 60 * 60))
+	})
+
+	t.Run("between", func(t *testing.T) {
+		from := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2024, time.March, 5, 23, 59, 59, 0, time.UTC)
+		found, err := v.Between(from, to, years.O_FUTURE(), years.O_LEAVES_ONLY())
+		be.Require(t, err).To(be.Succeed())
+		be.Require(t, found).To(be.HaveLength(2))
+		be.Expect(t, found[0].Identifier()).To(be.Eq(gitFix))
+		be.Expect(t, found[1].Identifier()).To(be.Eq(gitFeature))
+	})
+
+	t.Run("navigate", func(t *testing.T) {
+		// authored at 11:00 +0100
+		w, err := v.Navigate("2024-03-02T10:00:00Z")
+		be.Require(t, err).To(be.Succeed())
+		be.Require(t, w).NotTo(be.Nil())
+		be.Expect(t, w.Identifier()).To(be.Eq(gitParser))
+	})
+}
+
+func TestGitWaypoint_CommitterTime(t *testing.T) {
+	voyagerSetup(t)
+	from := time.Date(2024, time.March, 3, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.March, 3, 23, 59, 59, 0, time.UTC)
+
+	// "Add parser" was authored on March 2nd, but committed on March 3rd
+	repo, err := years.NewGitWaypoint(testGitRepoPath)
+	be.Require(t, err).To(be.Succeed())
+	found, err := years.NewVoyager(repo).Between(from, to, years.O_LEAVES_ONLY())
+	be.Require(t, err).To(be.Succeed())
+	be.Expect(t, found).To(be.Empty())
+
+	repo, err = years.NewGitWaypoint(testGitRepoPath, years.WithGitCommitterTime())
+	be.Require(t, err).To(be.Succeed())
+	found, err = years.NewVoyager(repo).Between(from, to, years.O_LEAVES_ONLY())
+	be.Require(t, err).To(be.Succeed())
+	be.Require(t, found).To(be.HaveLength(1))
+	be.Expect(t, found[0].Identifier()).To(be.Eq(gitParser))
+}
+
+func TestGitWaypoint_GroupByBranch(t *testing.T) {
+	voyagerSetup(t)
+
+	t.Run("all branches", func(t *testing.T) {
+		repo, err := years.NewGitWaypoint(testGitRepoPath, years.WithGitGroupByBranch())
+		be.Require(t, err).To(be.Succeed())
+
+		// HEAD's branch goes first, so the feature branch has only commits not reachable from main
+		be.Expect(t, childrenIdentifiers(repo)).To(be.Eq([]string{"main", "feature"}))
+		be.Expect(t, sorted(childrenIdentifiers(repo.Children()[0]))).To(be.Eq(sorted([]string{
+			gitInitial, gitParser, gitFix, gitMerge, gitFeature, gitContinue,
+		})))
+		be.Expect(t, repo.Children()[1].Children()).To(be.Empty())
+	})
+
+	t.Run("feature first", func(t *testing.T) {
+		repo, err := years.NewGitWaypoint(testGitRepoPath,
+			years.WithGitGroupByBranch(), years.WithGitBranches("feature", "main"),
+		)
+		be.Require(t, err).To(be.Succeed())
+
+		be.Expect(t, childrenIdentifiers(repo)).To(be.Eq([]string{"feature", "main"}))
+		be.Expect(t, sorted(childrenIdentifiers(repo.Children()[0]))).To(be.Eq(sorted([]string{
+			gitInitial, gitParser, gitFeature, gitContinue,
+		})))
+		be.Expect(t, sorted(childrenIdentifiers(repo.Children()[1]))).To(be.Eq(sorted([]string{
+			gitFix, gitMerge,
+		})))
+	})
+
+	t.Run("unknown branch", func(t *testing.T) {
+		_, err := years.NewGitWaypoint(testGitRepoPath, years.WithGitBranches("unknown"))
+		be.Expect(t, err).To(be.HaveOccurred())
+	})
+}
+
+func TestGitWaypoint_LinkedWorktree(t *testing.T) {
+	voyagerSetup(t)
+	common, err := filepath.Abs(testGitRepoPath)
+	be.Require(t, err).To(be.Succeed())
+
+	// linked worktree: ".git" file points to its own git directory (with HEAD),
+	// that points to the main repository (with objects and refs) via "commondir"
+	root := t.TempDir()
+	worktree, gitDir := filepath.Join(root, "worktree"), filepath.Join(root, "worktrees", "feature")
+	be.Require(t, os.MkdirAll(worktree, 0o755)).To(be.Succeed())
+	be.Require(t, os.MkdirAll(gitDir, 0o755)).To(be.Succeed())
+	be.Require(t, os.WriteFile(filepath.Join(worktree, ".git"), []byte("gitdir: "+gitDir+"\n"), 0o600)).To(be.Succeed())
+	be.Require(t, os.WriteFile(filepath.Join(gitDir, "HEAD"), []byte("ref: refs/heads/feature\n"), 0o600)).To(be.Succeed())
+	commonDir, err := filepath.Rel(gitDir, common)
+	be.Require(t, err).To(be.Succeed())
+	be.Require(t, os.WriteFile(filepath.Join(gitDir, "commondir"), []byte(commonDir+"\n"), 0o600)).To(be.Succeed())
+
+	repo, err := years.NewGitWaypoint(worktree, years.WithGitGroupByBranch())
+	be.Require(t, err).To(be.Succeed())
+
+	// worktree's HEAD goes first
+	be.Expect(t, childrenIdentifiers(repo)).To(be.Eq([]string{"feature", "main"}))
+	be.Expect(t, sorted(childrenIdentifiers(repo.Children()[0]))).To(be.Eq(sorted([]string{
+		gitInitial, gitParser, gitFeature, gitContinue,
+	})))
+	be.Expect(t, sorted(childrenIdentifiers(repo.Children()[1]))).To(be.Eq(sorted([]string{
+		gitFix, gitMerge,
+	})))
+}
+
+func TestGitWaypoint_NotARepository(t *testing.T) {
+	_, err := years.NewGitWaypoint(t.TempDir())
+	be.Expect(t, err).To(be.HaveOccurred())
+}
@@ -1,23 +1,171 @@
 package years
 
-import "time"
+import (
+	"slices"
+	"sync"
+	"time"
+)
 
 // WaypointGroup stands for a simple implementation of Waypoint that is
 // a container for other waypoints.
 type WaypointGroup struct {
 	waypoints  []Waypoint
 	identifier string
+
+	// timePolicy computes group's time from its children (nil means no time)
+	timePolicy GroupTimePolicy
+	// t is the time computed via timePolicy (it's valid if timeComputed is true)
+	t            time.Time
+	timeComputed bool
+	mu           sync.RWMutex
 }
 
-// NewWaypointGroup create a group for given waypoints.
+// GroupTimePolicy computes the time of a group from its children.
+type GroupTimePolicy func(children []Waypoint) time.Time
+
+// GroupTimeNone means the group has no time (so it's a non calendar waypoint). It's the default policy.
+func GroupTimeNone() GroupTimePolicy {
+	return func([]Waypoint) time.Time { return time.Time{} }
+}
+
+// GroupTimeEarliest means the group's time is the earliest time of its children (non calendar ones are ignored).
+func GroupTimeEarliest() GroupTimePolicy {
+	return func(children []Waypoint) time.Time {
+		var earliest time.Time
+		for _, child := range children {
+			if t := child.Time(); !t.IsZero() && (earliest.IsZero() || t.Before(earliest)) {
+				earliest = t
+			}
+		}
+		return earliest
+	}
+}
+
+// GroupTimeLatest means the group's time is the latest time of its children (non calendar ones are ignored).
+func GroupTimeLatest() GroupTimePolicy {
+	return func(children []Waypoint) time.Time {
+		var latest time.Time
+		for _, child := range children {
+			if t := child.Time(); t.After(latest) {
+				latest = t
+			}
+		}
+		return latest
+	}
+}
+
+// GroupTimeFirstChild means the group's time is the time of its first child (in the order children are given).
+func GroupTimeFirstChild() GroupTimePolicy {
+	return func(children []Waypoint) time.Time {
+		if len(children) == 0 {
+			return time.Time{}
+		}
+		return children[0].Time()
+	}
+}
+
+// GroupTimeFixed means the group's time is the given one, regardless of its children.
+func GroupTimeFixed(t time.Time) GroupTimePolicy {
+	return func([]Waypoint) time.Time { return t }
+}
+
+// NewWaypointGroup create a group for given waypoints. The group has no time (see NewTimedWaypointGroup).
 func NewWaypointGroup(identifier string, waypoints ...Waypoint) Waypoint {
 	return &WaypointGroup{identifier: identifier, waypoints: waypoints}
 }
 
-// Time returns group's time. For now group itself doesn't have a specific time.
-// TODO(nice-to-have): this maybe configurable,
-// e.g. no-time/min-time(children)/max-time(children)/time(children[0]), etc.
-func (wg *WaypointGroup) Time() time.Time      { return time.Time{} }
-func (wg *WaypointGroup) Identifier() string   { return wg.identifier }
-func (wg *WaypointGroup) IsContainer() bool    { return true }
-func (wg *WaypointGroup) Children() []Waypoint { return wg.waypoints }
+// NewTimedWaypointGroup creates a group for given waypoints, which time is computed via the given policy,
+// so the group participates in chronological traversing and navigation as a calendar waypoint.
+// The time is recomputed when children change (see Add).
+func NewTimedWaypointGroup(identifier string, policy GroupTimePolicy, waypoints ...Waypoint) *WaypointGroup {
+	return &WaypointGroup{identifier: identifier, waypoints: waypoints, timePolicy: policy}
+}
+
+// Time returns group's time computed via its time policy (zero by default, see NewTimedWaypointGroup).
+func (wg *WaypointGroup) Time() time.Time {
+	if wg.timePolicy == nil {
+		return time.Time{}
+	}
+
+	wg.mu.Lock()
+	defer wg.mu.Unlock()
+
+	if !wg.timeComputed {
+		wg.t = wg.timePolicy(wg.waypoints)
+		wg.timeComputed = true
+	}
+
+	return wg.t
+}
+
+func (wg *WaypointGroup) Identifier() string { return wg.identifier }
+func (wg *WaypointGroup) IsContainer() bool  { return true }
+
+// Children returns a copy of group's children, so it's safe to use while waypoints are being added.
+func (wg *WaypointGroup) Children() []Waypoint {
+	wg.mu.RLock()
+	defer wg.mu.RUnlock()
+
+	return slices.Clone(wg.waypoints)
+}
+
+// Add adds given waypoints to the group's children. Group's time is recomputed.
+func (wg *WaypointGroup) Add(waypoints ...Waypoint) {
+	wg.mu.Lock()
+	defer wg.mu.Unlock()
+
+	wg.waypoints = append(wg.waypoints, waypoints...)
+	wg.timeComputed = false
+}
+
+// Start returns the beginning of the time span covered by group's children (and group's own time,
+// if it has a time policy). See SpanWaypoint. It's zero if the span is unknown (see End).
+func (wg *WaypointGroup) Start() time.Time {
+	start, _ := wg.span()
+	return start
+}
+
+// End returns the end (exclusive) of the time span covered by group's children. See SpanWaypoint.
+// Instant children are covered up to the nanosecond right after the latest of them.
+func (wg *WaypointGroup) End() time.Time {
+	_, end := wg.span()
+	return end
+}
+
+// span returns the time span covered by group's children and group's own time (e.g. GroupTimeFixed one
+// may be out of children's span), so the group is not pruned when navigating to its time.
+// Non calendar leaves are ignored. Containers not knowing their span may have descendants anywhere,
+// so the span is unknown (zero) if there are any.
+func (wg *WaypointGroup) span() (start, end time.Time) {
+	if t := wg.Time(); !t.IsZero() {
+		start, end = t, t.Add(time.Nanosecond)
+	}
+
+	for _, child := range wg.Children() {
+		childStart, childEnd := child.Time(), child.Time()
+		if child.IsContainer() {
+			childSpan, ok := SpanOf(child)
+			if !ok {
+				return time.Time{}, time.Time{}
+			}
+			childStart, childEnd = childSpan.Start, childSpan.End
+		} else if sw, ok := child.(SpanWaypoint); ok {
+			childStart, childEnd = sw.Start(), sw.End()
+		}
+		if childStart.IsZero() {
+			continue
+		}
+		if !childEnd.After(childStart) {
+			childEnd = childStart.Add(time.Nanosecond)
+		}
+
+		if start.IsZero() || childStart.Before(start) {
+			start = childStart
+		}
+		if childEnd.After(end) {
+			end = childEnd
+		}
+	}
+
+	return start, end
+}
@@ -0,0 +1,95 @@
+package years_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/amberpixels/years"
+	"github.com/expectto/be"
+)
+
+func TestWaypointGroup_TimePolicy(t *testing.T) {
+	voyagerSetup(t)
+
+	children := func() []years.Waypoint {
+		return years.WaypointsFromStrings([]string{"2024-03-05", "not-a-time", "2024-03-01", "2024-03-09"})
+	}
+	date := func(day int) time.Time { return time.Date(2024, time.March, day, 0, 0, 0, 0, time.UTC) }
+
+	t.Run("policies", func(t *testing.T) {
+		be.Expect(t, years.NewWaypointGroup("default", children()...).Time().IsZero()).To(be.True())
+		be.Expect(t, years.NewTimedWaypointGroup("none", years.GroupTimeNone(), children()...).Time().IsZero()).
+			To(be.True())
+		be.Expect(t, years.NewTimedWaypointGroup("earliest", years.GroupTimeEarliest(), children()...).Time()).
+			To(be.Eq(date(1)))
+		be.Expect(t, years.NewTimedWaypointGroup("latest", years.GroupTimeLatest(), children()...).Time()).
+			To(be.Eq(date(9)))
+		be.Expect(t, years.NewTimedWaypointGroup("first", years.GroupTimeFirstChild(), children()...).Time()).
+			To(be.Eq(date(5)))
+		be.Expect(t, years.NewTimedWaypointGroup("fixed", years.GroupTimeFixed(date(20)), children()...).Time()).
+			To(be.Eq(date(20)))
+		be.Expect(t, years.NewTimedWaypointGroup("empty", years.GroupTimeFirstChild()).Time().IsZero()).To(be.True())
+	})
+
+	t.Run("recomputed when children change", func(t *testing.T) {
+		group := years.NewTimedWaypointGroup("latest", years.GroupTimeLatest(), children()...)
+		be.Expect(t, group.Time()).To(be.Eq(date(9)))
+
+		group.Add(years.NewWaypointString("2024-03-15"))
+		be.Expect(t, group.Time()).To(be.Eq(date(15)))
+		be.Expect(t, group.Children()).To(be.HaveLength(5))
+	})
+
+	t.Run("concurrent access", func(t *testing.T) {
+		group := years.NewTimedWaypointGroup("latest", years.GroupTimeLatest())
+
+		var wg sync.WaitGroup
+		for _, day := range []string{"2024-03-01", "2024-03-02", "2024-03-03", "2024-03-04"} {
+			wg.Go(func() {
+				group.Add(years.NewWaypointString(day))
+				_ = group.Children()
+				_ = group.End()
+			})
+		}
+		wg.Wait()
+
+		be.Expect(t, group.Children()).To(be.HaveLength(4))
+		be.Expect(t, group.Time()).To(be.Eq(date(4)))
+	})
+
+	t.Run("fixed time out of children's span", func(t *testing.T) {
+		may := time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)
+		group := years.NewTimedWaypointGroup("fixed", years.GroupTimeFixed(may), children()...)
+		v := years.NewVoyager(years.NewWaypointGroup("root", group))
+		be.Expect(t, group.Start()).To(be.Eq(date(1)))
+
+		navigated, err := v.Navigate("2024-05-01")
+		be.Require(t, err).To(be.Succeed())
+		be.Require(t, navigated).NotTo(be.Nil())
+		be.Expect(t, navigated.Identifier()).To(be.Eq("fixed"))
+
+		found, err := v.Between(may.Add(-time.Hour), may.Add(time.Hour), years.O_ALL())
+		be.Require(t, err).To(be.Succeed())
+		be.Require(t, found).To(be.HaveLength(1))
+		be.Expect(t, found[0].Identifier()).To(be.Eq("fixed"))
+	})
+
+	t.Run("participates in traversing", func(t *testing.T) {
+		root := years.NewWaypointGroup("root",
+			years.NewTimedWaypointGroup("week", years.GroupTimeEarliest(), years.WaypointsFromStrings([]string{
+				"2024-03-04", "2024-03-06",
+			})...),
+			years.NewWaypointString("2024-03-05"),
+		)
+		v := years.NewVoyager(root)
+
+		be.Expect(t, collectTraverse(t, v, years.O_FUTURE(), years.O_ALL())).To(be.Eq([]string{
+			"week", "2024-03-04", "2024-03-05", "2024-03-06",
+		}))
+
+		navigated, err := v.Navigate("2024-03-04")
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, navigated.Identifier()).To(be.Eq("2024-03-04"))
+	})
+}
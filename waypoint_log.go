@@ -0,0 +1,162 @@
+package years
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LineTimeExtractor extracts the time a log line starts with.
+type LineTimeExtractor func(line []byte) (time.Time, bool)
+
+// LineTimestamp extracts the time a line starts with (optionally in brackets) via the given layout,
+// e.g. "2006-01-02 15:04:05" for "2024-03-05 10:11:12 INFO started".
+// If the layout is empty, all layouts of the parser are tried. Default parser is used if not given:
+// then numeric timestamps are accepted only via timestamp layouts, e.g. "U@" for "1709632800 INFO started",
+// so lines like "42 items processed" are not taken as 1970 times.
+func LineTimestamp(layout string, parserArg ...*Parser) LineTimeExtractor {
+	var parser *Parser
+	if len(parserArg) > 0 {
+		parser = parserArg[0]
+	} else {
+		// Default parser is used. Use years.SetParserDefaults to configure parsing
+		parser = NewParser()
+		if !strings.Contains(layout, LayoutTimestampSeconds) {
+			parser = parser.withoutEpochs()
+		}
+	}
+
+	return func(line []byte) (time.Time, bool) {
+		return parseLinePrefix(string(line), parser, layout)
+	}
+}
+
+// LogFileWaypoint is a Waypoint implementation for a text (log) file: it's a container of its records,
+// i.e. lines starting with a timestamp (see LogLineWaypoint).
+// Lines not starting with a timestamp (e.g. stack traces) are attached to the preceding record.
+type LogFileWaypoint struct {
+	path string
+
+	records []Waypoint
+}
+
+// NewLogFileWaypoint creates a waypoint for the log file at the given path, reading the time of its lines
+// via the given extractor (e.g. LineTimestamp("2006-01-02 15:04:05")).
+// The file is scanned once: only offsets and times of records are kept, their text is read on demand.
+// Lines preceding the first timestamped one make a non calendar record.
+func NewLogFileWaypoint(path string, extractor LineTimeExtractor) (*LogFileWaypoint, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open log file: %w", err)
+	}
+	defer f.Close()
+
+	w := &LogFileWaypoint{path: path}
+
+	var current *LogLineWaypoint
+	var offset int64
+	reader := bufio.NewReader(f)
+	for lineNumber := 1; ; lineNumber++ {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("could not read log file: %w", err)
+		}
+		if len(line) == 0 {
+			break
+		}
+
+		t, ok := extractor(line)
+		if ok || current == nil {
+			current = &LogLineWaypoint{file: w, offset: offset, lineNumber: lineNumber, t: t}
+			w.records = append(w.records, current)
+		}
+		current.length += int64(len(line))
+		offset += int64(len(line))
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+	}
+
+	return w, nil
+}
+
+// Time returns the time of the first timestamped record (zero if there are none).
+func (w *LogFileWaypoint) Time() time.Time { return w.Start() }
+
+func (w *LogFileWaypoint) Identifier() string   { return w.path }
+func (w *LogFileWaypoint) IsContainer() bool    { return true }
+func (w *LogFileWaypoint) Children() []Waypoint { return w.records }
+
+// Start returns the time of the first timestamped record. See SpanWaypoint.
+func (w *LogFileWaypoint) Start() time.Time {
+	for _, record := range w.records {
+		if t := record.Time(); !t.IsZero() {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// End returns the nanosecond right after the time of the last timestamped record. See SpanWaypoint.
+// Note: records are expected to be sorted by time (as logs usually are).
+func (w *LogFileWaypoint) End() time.Time {
+	for i := len(w.records) - 1; i >= 0; i-- {
+		if t := w.records[i].Time(); !t.IsZero() {
+			return t.Add(time.Nanosecond)
+		}
+	}
+	return time.Time{}
+}
+
+// LogLineWaypoint is a Waypoint implementation for a record of a log file (see LogFileWaypoint):
+// a timestamped line along with the following lines not having a timestamp (e.g. a stack trace).
+type LogLineWaypoint struct {
+	file *LogFileWaypoint
+
+	// offset and length are the byte range of the record in the file
+	offset int64
+	length int64
+
+	// lineNumber is the number (1-based) of the record's first line
+	lineNumber int
+
+	t time.Time
+}
+
+func (w *LogLineWaypoint) Time() time.Time      { return w.t }
+func (w *LogLineWaypoint) IsContainer() bool    { return false }
+func (w *LogLineWaypoint) Children() []Waypoint { return nil }
+
+// Identifier returns the file path with the line number, e.g. "app.log:42".
+func (w *LogLineWaypoint) Identifier() string { return w.file.path + ":" + strconv.Itoa(w.lineNumber) }
+
+// Offset returns the byte offset of the record in the file.
+func (w *LogLineWaypoint) Offset() int64 { return w.offset }
+
+// Length returns the byte length of the record (including all its lines).
+func (w *LogLineWaypoint) Length() int64 { return w.length }
+
+// LineNumber returns the number (1-based) of the record's first line.
+func (w *LogLineWaypoint) LineNumber() int { return w.lineNumber }
+
+// Text reads the record from the file (without the trailing newline).
+func (w *LogLineWaypoint) Text() (string, error) {
+	f, err := os.Open(w.file.path)
+	if err != nil {
+		return "", fmt.Errorf("could not open log file: %w", err)
+	}
+	defer f.Close()
+
+	buf := make([]byte, w.length)
+	if _, err := f.ReadAt(buf, w.offset); err != nil {
+		return "", fmt.Errorf("could not read log record: %w", err)
+	}
+
+	return strings.TrimRight(string(buf), "\r\n"), nil
+}
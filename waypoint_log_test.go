@@ -0,0 +1,94 @@
+package years_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/amberpixels/years"
+	"github.com/expectto/be"
+)
+
+const testLog = `starting (no timestamp)
+2024-03-05 10:00:00 INFO started
+2024-03-05 10:05:00 ERROR failed
+    at main.go:42
+    at main.go:7
+2024-03-05 10:10:00 INFO recovered
+[2024-03-05 10:15:00] INFO done`
+
+// writeLog writes the given content into a log file in a temporary directory.
+func writeLog(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "app.log")
+	be.Require(t, os.WriteFile(path, []byte(content), 0o600)).To(be.Succeed())
+	return path
+}
+
+func TestLogFileWaypoint(t *testing.T) {
+	voyagerSetup(t, "2006-01-02 15:04:05")
+	path := writeLog(t, testLog)
+	at := func(minute int) time.Time { return time.Date(2024, time.March, 5, 10, minute, 0, 0, time.UTC) }
+
+	wf, err := years.NewLogFileWaypoint(path, years.LineTimestamp(years.LayoutDateTime))
+	be.Require(t, err).To(be.Succeed())
+	be.Expect(t, wf.Children()).To(be.HaveLength(5))
+	be.Expect(t, wf.Time()).To(be.Eq(at(0)))
+	be.Expect(t, wf.End()).To(be.Eq(at(15).Add(time.Nanosecond)))
+
+	t.Run("records", func(t *testing.T) {
+		failed := wf.Children()[2].(*years.LogLineWaypoint)
+		be.Expect(t, failed.Identifier()).To(be.Eq(path + ":3"))
+		be.Expect(t, failed.Time()).To(be.Eq(at(5)))
+		be.Expect(t, failed.Offset()).To(be.Eq(int64(len("starting (no timestamp)\n2024-03-05 10:00:00 INFO started\n"))))
+
+		text, err := failed.Text()
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, text).To(be.Eq("2024-03-05 10:05:00 ERROR failed\n    at main.go:42\n    at main.go:7"))
+
+		last := wf.Children()[4].(*years.LogLineWaypoint)
+		be.Expect(t, last.LineNumber()).To(be.Eq(7))
+		text, err = last.Text()
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, text).To(be.Eq("[2024-03-05 10:15:00] INFO done"))
+
+		be.Expect(t, wf.Children()[0].Time().IsZero()).To(be.True())
+	})
+
+	t.Run("numeric lines are not timestamps", func(t *testing.T) {
+		path := writeLog(t, "2024-03-05 10:00:00 INFO started\n42\n42 items processed\n2024-03-05 10:05:00 INFO done")
+
+		wf, err := years.NewLogFileWaypoint(path, years.LineTimestamp(""))
+		be.Require(t, err).To(be.Succeed())
+		be.Require(t, wf.Children()).To(be.HaveLength(2))
+		text, err := wf.Children()[0].(*years.LogLineWaypoint).Text()
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, text).To(be.Eq("2024-03-05 10:00:00 INFO started\n42\n42 items processed"))
+
+		// unless timestamps are expected by the layout
+		path = writeLog(t, "1709632800 INFO started\n    at main.go:42")
+		wf, err = years.NewLogFileWaypoint(path, years.LineTimestamp(years.LayoutTimestampSeconds))
+		be.Require(t, err).To(be.Succeed())
+		be.Require(t, wf.Children()).To(be.HaveLength(1))
+		be.Expect(t, wf.Time()).To(be.Eq(at(0)))
+
+		// or by the given parser
+		wf, err = years.NewLogFileWaypoint(path, years.LineTimestamp("", years.NewParser(years.AcceptUnixSeconds())))
+		be.Require(t, err).To(be.Succeed())
+		be.Require(t, wf.Children()).To(be.HaveLength(1))
+		be.Expect(t, wf.Time()).To(be.Eq(at(0)))
+	})
+
+	t.Run("navigating", func(t *testing.T) {
+		v := years.NewVoyager(wf)
+		navigated, err := v.Navigate("2024-03-05 10:10:00")
+		be.Require(t, err).To(be.Succeed())
+		be.Require(t, navigated).NotTo(be.Nil())
+		be.Expect(t, navigated.Identifier()).To(be.Eq(path + ":6"))
+
+		found, err := v.Between(at(3), at(12), years.O_FUTURE())
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, found).To(be.HaveLength(3)) // the file itself and two records
+	})
+}
@@ -0,0 +1,161 @@
+package years
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// WaypointOf is a Waypoint implementation wrapping an arbitrary Go value (e.g. a domain object like an invoice).
+// The wrapped value is available via Value() (or via ValueOf for waypoints got from Voyager).
+type WaypointOf[T any] struct {
+	value      T
+	identifier string
+	t          time.Time
+}
+
+// NewWaypointOf creates a waypoint for the given value with the given identifier and time.
+func NewWaypointOf[T any](value T, identifier string, t time.Time) *WaypointOf[T] {
+	return &WaypointOf[T]{value: value, identifier: identifier, t: t}
+}
+
+func (w *WaypointOf[T]) Time() time.Time      { return w.t }
+func (w *WaypointOf[T]) Identifier() string   { return w.identifier }
+func (w *WaypointOf[T]) IsContainer() bool    { return false }
+func (w *WaypointOf[T]) Children() []Waypoint { return nil }
+
+// Value returns the wrapped value.
+func (w *WaypointOf[T]) Value() T { return w.value }
+
+// ValueOf returns the value wrapped by the given waypoint, if it's a WaypointOf[T].
+// It's handy for waypoints got from Voyager, e.g.:
+//
+//	for w := range years.NewVoyager(group).All() {
+//		invoice, _ := years.ValueOf[Invoice](w)
+//	}
+func ValueOf[T any](w Waypoint) (T, bool) {
+	wo, ok := w.(*WaypointOf[T])
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return wo.value, true
+}
+
+// NewWaypointGroupOf creates a group of waypoints for the given values, taking their time via timeOf
+// and their identifiers via identifierOf (if it's nil, indexes of values are identifiers).
+func NewWaypointGroupOf[T any](
+	identifier string, values []T,
+	timeOf func(value T) time.Time, identifierOf func(value T) string,
+) *WaypointGroup {
+	waypoints := make([]Waypoint, len(values))
+	for i, value := range values {
+		id := strconv.Itoa(i)
+		if identifierOf != nil {
+			id = identifierOf(value)
+		}
+		waypoints[i] = NewWaypointOf(value, id, timeOf(value))
+	}
+
+	return &WaypointGroup{identifier: identifier, waypoints: waypoints}
+}
+
+// Struct tags recognized by NewWaypointGroupOfTagged.
+const (
+	// TagName is the struct tag key, e.g. `years:"time"`.
+	TagName = "years"
+	// TagTime marks the field holding the time: time.Time, *time.Time or a string (parsed via the default parser).
+	TagTime = "time"
+	// TagIdentifier marks the field holding the identifier (formatted via fmt.Sprint).
+	TagIdentifier = "id"
+)
+
+// NewWaypointGroupOfTagged creates a group of waypoints for the given structs (or pointers to structs),
+// taking their time from the field tagged with `years:"time"` and their identifiers from the field tagged
+// with `years:"id"` (if there is none, indexes of values are identifiers).
+// Time strings that can't be parsed (as well as nil pointers) make non calendar waypoints.
+func NewWaypointGroupOfTagged[T any](identifier string, values []T) (*WaypointGroup, error) {
+	structType := reflect.TypeFor[T]()
+	if structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("tagged values must be structs, got %s", structType)
+	}
+
+	timeField, idField := -1, -1
+	for i := range structType.NumField() {
+		field := structType.Field(i)
+		tag := field.Tag.Get(TagName)
+		if tag != "" && !field.IsExported() {
+			return nil, fmt.Errorf("field %s tagged with `%s:\"%s\"` must be exported", field.Name, TagName, tag)
+		}
+
+		switch tag {
+		case TagTime:
+			timeField = i
+		case TagIdentifier:
+			idField = i
+		}
+	}
+	if timeField < 0 {
+		return nil, fmt.Errorf("%s has no field tagged with `%s:\"%s\"`", structType, TagName, TagTime)
+	}
+
+	switch fieldType := structType.Field(timeField).Type; fieldType {
+	case reflect.TypeFor[time.Time](), reflect.TypeFor[*time.Time](), reflect.TypeFor[string]():
+	default:
+		return nil, fmt.Errorf("unsupported type of time field %s: %s", structType.Field(timeField).Name, fieldType)
+	}
+
+	structOf := func(value T) (reflect.Value, bool) {
+		v := reflect.ValueOf(&value).Elem()
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		return v, true
+	}
+
+	timeOf := func(value T) time.Time {
+		v, ok := structOf(value)
+		if !ok {
+			return time.Time{}
+		}
+
+		switch fieldValue := v.Field(timeField).Interface().(type) {
+		case time.Time:
+			return fieldValue
+		case *time.Time:
+			if fieldValue == nil {
+				return time.Time{}
+			}
+			return *fieldValue
+		case string:
+			// Default parser is used. Use years.SetParserDefaults to configure parsing
+			t, err := NewParser().JustParse(fieldValue)
+			if err != nil {
+				return time.Time{}
+			}
+			return t
+		default:
+			panic("unreachable: type of time field is checked")
+		}
+	}
+
+	var identifierOf func(value T) string
+	if idField >= 0 {
+		identifierOf = func(value T) string {
+			v, ok := structOf(value)
+			if !ok {
+				return ""
+			}
+			return fmt.Sprint(v.Field(idField).Interface())
+		}
+	}
+
+	return NewWaypointGroupOf(identifier, values, timeOf, identifierOf), nil
+}
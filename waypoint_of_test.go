@@ -0,0 +1,89 @@
+package years_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/amberpixels/years"
+	"github.com/expectto/be"
+)
+
+type testInvoice struct {
+	Number   int       `years:"id"`
+	IssuedAt time.Time `years:"time"`
+	Amount   float64
+}
+
+func TestWaypointOf(t *testing.T) {
+	voyagerSetup(t)
+
+	date := func(day int) time.Time { return time.Date(2024, time.March, day, 0, 0, 0, 0, time.UTC) }
+	invoices := []testInvoice{
+		{Number: 1, IssuedAt: date(5), Amount: 10},
+		{Number: 2, IssuedAt: date(1), Amount: 20},
+		{Number: 3, IssuedAt: date(9), Amount: 30},
+	}
+
+	collectValues := func(t *testing.T, group years.Waypoint) []testInvoice {
+		t.Helper()
+		result := make([]testInvoice, 0)
+		for w := range years.NewVoyager(group).All(years.O_FUTURE()) {
+			invoice, ok := years.ValueOf[testInvoice](w)
+			be.Require(t, ok).To(be.True())
+			result = append(result, invoice)
+		}
+		return result
+	}
+
+	t.Run("via time accessor", func(t *testing.T) {
+		group := years.NewWaypointGroupOf("invoices", invoices,
+			func(invoice testInvoice) time.Time { return invoice.IssuedAt }, nil,
+		)
+		be.Expect(t, collectValues(t, group)).To(be.Eq([]testInvoice{invoices[1], invoices[0], invoices[2]}))
+		be.Expect(t, group.Children()[1].Identifier()).To(be.Eq("1"))
+
+		w := group.Children()[0].(*years.WaypointOf[testInvoice])
+		be.Expect(t, w.Value().Amount).To(be.Eq(10.0))
+	})
+
+	t.Run("via struct tags", func(t *testing.T) {
+		group, err := years.NewWaypointGroupOfTagged("invoices", invoices)
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, collectValues(t, group)).To(be.Eq([]testInvoice{invoices[1], invoices[0], invoices[2]}))
+		be.Expect(t, group.Children()[2].Identifier()).To(be.Eq("3"))
+
+		type event struct {
+			Name string  `years:"id"`
+			At   *string `years:"time"`
+		}
+		_, err = years.NewWaypointGroupOfTagged("events", []event{{Name: "a"}})
+		be.Expect(t, err).To(be.HaveOccurred())
+	})
+
+	t.Run("pointers and time strings", func(t *testing.T) {
+		type event struct {
+			Name string `years:"id"`
+			At   string `years:"time"`
+		}
+		events := []*event{{Name: "b", At: "2024-03-05"}, {Name: "a", At: "2024-03-04"}, {Name: "broken", At: "?"}, nil}
+
+		group, err := years.NewWaypointGroupOfTagged("events", events)
+		be.Require(t, err).To(be.Succeed())
+
+		names := make([]string, 0)
+		for w := range years.NewVoyager(group).All(years.O_FUTURE()) {
+			e, ok := years.ValueOf[*event](w)
+			be.Require(t, ok).To(be.True())
+			names = append(names, e.Name)
+		}
+		be.Expect(t, names).To(be.Eq([]string{"a", "b"}))
+
+		_, ok := years.ValueOf[event](group.Children()[0])
+		be.Expect(t, ok).To(be.False())
+	})
+
+	t.Run("not structs", func(t *testing.T) {
+		_, err := years.NewWaypointGroupOfTagged("ints", []int{1, 2})
+		be.Expect(t, err).To(be.HaveOccurred())
+	})
+}
@@ -0,0 +1,297 @@
+package years
+
+import (
+	"bufio"
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Record is a single record (CSV row, JSON line, SQL row) of a waypoint built via record adapters
+// (see NewCSVWaypoints, NewJSONLWaypoints, NewSQLWaypoints). Records are available via ValueOf[Record].
+type Record map[string]any
+
+// Text returns the value of the given column formatted as a string ("" if there is no such column).
+func (r Record) Text(column string) string {
+	v, ok := r[column]
+	if !ok || v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// recordsConfig holds configuration for building waypoints from records.
+type recordsConfig struct {
+	parser           *Parser
+	identifierColumn string
+	groupBy          []string
+	groupTimePolicy  GroupTimePolicy
+}
+
+// RecordOption defines functional options for record adapters.
+type RecordOption func(*recordsConfig)
+
+// WithRecordParser opts to parse time values via the given parser (the default one is used otherwise).
+func WithRecordParser(parser *Parser) RecordOption {
+	return func(c *recordsConfig) { c.parser = parser }
+}
+
+// WithIdentifierColumn opts to take identifiers of waypoints from the given column
+// (by default identifiers are indexes of records).
+func WithIdentifierColumn(column string) RecordOption {
+	return func(c *recordsConfig) { c.identifierColumn = column }
+}
+
+// WithGroupBy opts to nest records in groups (WaypointGroup) by values of the given columns,
+// e.g. WithGroupBy("country", "city") makes a group per country with a group per city inside.
+// Groups are identified by column values and are ordered by the first appearance.
+func WithGroupBy(columns ...string) RecordOption {
+	return func(c *recordsConfig) { c.groupBy = columns }
+}
+
+// WithGroupTimePolicy opts to compute time of groups (see WithGroupBy) via the given policy.
+// By default groups have no time.
+func WithGroupTimePolicy(policy GroupTimePolicy) RecordOption {
+	return func(c *recordsConfig) { c.groupTimePolicy = policy }
+}
+
+// recordsBuilder builds a tree of waypoints from records.
+type recordsBuilder struct {
+	config     recordsConfig
+	timeColumn string
+
+	root   *WaypointGroup
+	groups map[string]*WaypointGroup
+	count  int
+}
+
+func newRecordsBuilder(timeColumn string, opts ...RecordOption) *recordsBuilder {
+	b := &recordsBuilder{
+		timeColumn: timeColumn,
+		root:       &WaypointGroup{},
+		groups:     make(map[string]*WaypointGroup),
+	}
+	for _, opt := range opts {
+		opt(&b.config)
+	}
+	if b.config.parser == nil {
+		// Default parser is used. Use years.SetParserDefaults to configure parsing
+		b.config.parser = NewParser()
+	}
+
+	return b
+}
+
+// checkColumns ensures all configured columns are present.
+func (b *recordsBuilder) checkColumns(columns []string) error {
+	required := append([]string{b.timeColumn}, b.config.groupBy...)
+	if b.config.identifierColumn != "" {
+		required = append(required, b.config.identifierColumn)
+	}
+
+	for _, column := range required {
+		if !slices.Contains(columns, column) {
+			return fmt.Errorf("column %q not found", column)
+		}
+	}
+
+	return nil
+}
+
+// add adds the record to the tree. Records having unparseable time become non calendar waypoints.
+func (b *recordsBuilder) add(record Record) {
+	identifier := strconv.Itoa(b.count)
+	if b.config.identifierColumn != "" {
+		identifier = record.Text(b.config.identifierColumn)
+	}
+	b.count++
+
+	t, _ := b.parseTime(record[b.timeColumn])
+	waypoint := NewWaypointOf(record, identifier, t)
+
+	parent := b.root
+	var key strings.Builder
+	for _, column := range b.config.groupBy {
+		value := record.Text(column)
+		key.WriteString(value)
+		key.WriteByte(0)
+
+		group, ok := b.groups[key.String()]
+		if !ok {
+			group = &WaypointGroup{identifier: value, timePolicy: b.config.groupTimePolicy}
+			b.groups[key.String()] = group
+			parent.Add(group)
+		}
+		parent = group
+	}
+	parent.Add(waypoint)
+}
+
+// parseTime parses time from a record value.
+func (b *recordsBuilder) parseTime(value any) (time.Time, error) {
+	switch v := value.(type) {
+	case nil:
+		return time.Time{}, errors.New("no time value")
+	case time.Time:
+		return v, nil
+	case *time.Time:
+		if v == nil {
+			return time.Time{}, errors.New("no time value")
+		}
+		return *v, nil
+	case json.Number:
+		if digits, err := v.Int64(); err == nil {
+			return unixTimeOf(digits), nil
+		}
+		return b.config.parser.Parse("", v.String())
+	case []byte:
+		return b.config.parser.Parse("", string(v))
+	case string:
+		return b.config.parser.Parse("", v)
+	default:
+		// numbers (e.g. unix timestamps) are parsed from their string form
+		return b.config.parser.Parse("", fmt.Sprint(v))
+	}
+}
+
+// unixTimeOf returns the time of the unix timestamp, detecting its unit by the magnitude:
+// values below 1e11 are seconds (up to year 5138), then milliseconds, microseconds and nanoseconds.
+func unixTimeOf(v int64) time.Time {
+	const (
+		maxSeconds = 1e11
+		maxMillis  = 1e14
+		maxMicros  = 1e17
+	)
+
+	switch abs := max(v, -v); {
+	case abs < maxSeconds:
+		return time.Unix(v, 0).UTC()
+	case abs < maxMillis:
+		return time.UnixMilli(v).UTC()
+	case abs < maxMicros:
+		return time.UnixMicro(v).UTC()
+	default:
+		return time.Unix(0, v).UTC()
+	}
+}
+
+// NewCSVWaypoints builds waypoints from CSV records (the first record is the header).
+// Time is taken from the given column and parsed via the parser (see WithRecordParser).
+// Records are available via ValueOf[Record] (all values are strings).
+func NewCSVWaypoints(r io.Reader, timeColumn string, opts ...RecordOption) (*WaypointGroup, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("could not read CSV header: %w", err)
+	}
+
+	b := newRecordsBuilder(timeColumn, opts...)
+	if err := b.checkColumns(header); err != nil {
+		return nil, err
+	}
+
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not read CSV record: %w", err)
+		}
+
+		record := make(Record, len(header))
+		for i, column := range header {
+			record[column] = row[i]
+		}
+		b.add(record)
+	}
+
+	return b.root, nil
+}
+
+// NewJSONLWaypoints builds waypoints from JSON Lines: each non-empty line is a JSON object.
+// Time is taken from the given (top-level) field: strings are parsed via the parser (see WithRecordParser),
+// integer numbers are unix timestamps in seconds, milliseconds, microseconds or nanoseconds
+// (detected by the magnitude, regardless of the parser). Lines missing the field become non calendar waypoints.
+// Records are available via ValueOf[Record] (numbers are json.Number).
+func NewJSONLWaypoints(r io.Reader, timeField string, opts ...RecordOption) (*WaypointGroup, error) {
+	b := newRecordsBuilder(timeField, opts...)
+
+	reader := bufio.NewReader(r)
+	for lineNumber := 1; ; lineNumber++ {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("could not read line %d: %w", lineNumber, err)
+		}
+
+		if trimmed := bytes.TrimSpace(line); len(trimmed) > 0 {
+			decoder := json.NewDecoder(bytes.NewReader(trimmed))
+			decoder.UseNumber()
+
+			var record Record
+			if err := decoder.Decode(&record); err != nil {
+				return nil, fmt.Errorf("could not decode line %d: %w", lineNumber, err)
+			}
+			b.add(record)
+		}
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+	}
+
+	return b.root, nil
+}
+
+// NewSQLWaypoints builds waypoints from SQL rows of any database/sql driver. Rows are read and closed.
+// Time is taken from the given column: time.Time values are taken as is,
+// others are parsed via the parser (see WithRecordParser).
+// Records are available via ValueOf[Record] (values are as scanned into `any`, []byte turned into strings).
+func NewSQLWaypoints(rows *sql.Rows, timeColumn string, opts ...RecordOption) (*WaypointGroup, error) {
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("could not get columns: %w", err)
+	}
+
+	b := newRecordsBuilder(timeColumn, opts...)
+	if err := b.checkColumns(columns); err != nil {
+		return nil, err
+	}
+
+	values := make([]any, len(columns))
+	pointers := make([]any, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, fmt.Errorf("could not scan row: %w", err)
+		}
+
+		record := make(Record, len(columns))
+		for i, column := range columns {
+			if raw, ok := values[i].([]byte); ok {
+				record[column] = string(raw)
+			} else {
+				record[column] = values[i]
+			}
+		}
+		b.add(record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("could not read rows: %w", err)
+	}
+
+	return b.root, nil
+}
@@ -0,0 +1,184 @@
+package years_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amberpixels/years"
+	"github.com/expectto/be"
+)
+
+// collectRecords returns identifiers of records in chronological order.
+func collectRecords(t *testing.T, root years.Waypoint, opts ...years.TraverseOption) []string {
+	t.Helper()
+	identifiers := make([]string, 0)
+	for w := range years.NewVoyager(root).All(append([]years.TraverseOption{years.O_FUTURE()}, opts...)...) {
+		identifiers = append(identifiers, w.Identifier())
+	}
+	return identifiers
+}
+
+func TestRecords_CSV(t *testing.T) {
+	voyagerSetup(t)
+	const data = `id,at,country,city
+a,2024-03-05,DE,Berlin
+b,2024-03-01,FR,Paris
+c,2024-03-03,DE,Munich
+d,2024-03-02,DE,Berlin
+e,not-a-time,FR,Paris
+`
+
+	t.Run("flat", func(t *testing.T) {
+		root, err := years.NewCSVWaypoints(strings.NewReader(data), "at", years.WithIdentifierColumn("id"))
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, collectRecords(t, root)).To(be.Eq([]string{"b", "d", "c", "a"}))
+
+		record, ok := years.ValueOf[years.Record](root.Children()[0])
+		be.Require(t, ok).To(be.True())
+		be.Expect(t, record.Text("city")).To(be.Eq("Berlin"))
+	})
+
+	t.Run("grouped", func(t *testing.T) {
+		root, err := years.NewCSVWaypoints(strings.NewReader(data), "at",
+			years.WithIdentifierColumn("id"), years.WithGroupBy("country", "city"),
+			years.WithGroupTimePolicy(years.GroupTimeEarliest()),
+		)
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, childrenIdentifiers(root)).To(be.Eq([]string{"DE", "FR"}))
+		be.Expect(t, childrenIdentifiers(root.Children()[0])).To(be.Eq([]string{"Berlin", "Munich"}))
+		be.Expect(t, collectRecords(t, root, years.O_ALL())).To(be.Eq([]string{
+			"FR", "Paris", "b", "DE", "Berlin", "d", "Munich", "c", "a",
+		}))
+	})
+
+	t.Run("custom parser", func(t *testing.T) {
+		const data = "at,v\n05.03.2024,x\n"
+		root, err := years.NewCSVWaypoints(strings.NewReader(data), "at",
+			years.WithRecordParser(years.NewParser(years.WithLayouts("02.01.2006"))),
+		)
+		be.Require(t, err).To(be.Succeed())
+		be.Expect(t, root.Children()[0].Time()).To(be.Eq(time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("missing column", func(t *testing.T) {
+		_, err := years.NewCSVWaypoints(strings.NewReader(data), "time")
+		be.Expect(t, err).To(be.HaveOccurred())
+	})
+}
+
+func TestRecords_JSONL(t *testing.T) {
+	voyagerSetup(t)
+	const data = `{"id": "a", "at": "2024-03-05", "kind": "x"}
+
+{"id": "b", "at": 1709251200, "kind": "y"}
+{"id": "c", "kind": "x"}
+{"id": "d", "at": "2024-03-03T10:00:00Z", "kind": "x"}`
+
+	root, err := years.NewJSONLWaypoints(strings.NewReader(data), "at",
+		years.WithIdentifierColumn("id"), years.WithGroupBy("kind"),
+	)
+	be.Require(t, err).To(be.Succeed())
+	be.Expect(t, collectRecords(t, root)).To(be.Eq([]string{"b", "d", "a"}))
+	be.Expect(t, childrenIdentifiers(root)).To(be.Eq([]string{"x", "y"}))
+
+	_, err = years.NewJSONLWaypoints(strings.NewReader(`{"at": `), "at")
+	be.Expect(t, err).To(be.HaveOccurred())
+
+	t.Run("unix timestamps of any unit", func(t *testing.T) {
+		const epochs = `{"id": "seconds", "at": 1709632800}
+{"id": "millis", "at": 1709632800000}
+{"id": "nanos", "at": 1709632800000000000}`
+
+		// the parser doesn't accept unix timestamps at all
+		root, err := years.NewJSONLWaypoints(strings.NewReader(epochs), "at",
+			years.WithIdentifierColumn("id"), years.WithRecordParser(years.NewParser(years.WithLayouts(time.RFC3339))),
+		)
+		be.Require(t, err).To(be.Succeed())
+		be.Require(t, root.Children()).To(be.HaveLength(3))
+		for _, w := range root.Children() {
+			be.Expect(t, w.Time().Equal(time.Date(2024, time.March, 5, 10, 0, 0, 0, time.UTC))).To(be.True())
+		}
+	})
+}
+
+func TestRecords_SQL(t *testing.T) {
+	voyagerSetup(t)
+	db := sql.OpenDB(fakeConnector{
+		columns: []string{"id", "created_at", "team"},
+		rows: [][]driver.Value{
+			{int64(1), time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC), "core"},
+			{int64(2), []byte("2024-03-01"), "infra"},
+			{int64(3), "2024-03-03 10:00:00", "core"},
+			{int64(4), nil, "core"},
+		},
+	})
+	t.Cleanup(func() { _ = db.Close() })
+
+	rows, err := db.Query("SELECT id, created_at, team FROM events")
+	be.Require(t, err).To(be.Succeed())
+
+	root, err := years.NewSQLWaypoints(rows, "created_at", years.WithIdentifierColumn("id"), years.WithGroupBy("team"))
+	be.Require(t, err).To(be.Succeed())
+	be.Expect(t, collectRecords(t, root)).To(be.Eq([]string{"2", "3", "1"}))
+	be.Expect(t, collectRecords(t, root, years.O_NON_CALENDAR(), years.O_LEAVES_ONLY())).To(be.HaveLength(4))
+
+	record, ok := years.ValueOf[years.Record](root.Children()[1].Children()[0])
+	be.Require(t, ok).To(be.True())
+	be.Expect(t, record["created_at"]).To(be.Eq("2024-03-01"))
+}
+
+// childrenIdentifiers returns identifiers of waypoint's children.
+func childrenIdentifiers(w years.Waypoint) []string {
+	identifiers := make([]string, 0)
+	for _, child := range w.Children() {
+		identifiers = append(identifiers, child.Identifier())
+	}
+	return identifiers
+}
+
+// fakeConnector is a database/sql driver returning the given rows for any query.
+type fakeConnector struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (c fakeConnector) Connect(_ context.Context) (driver.Conn, error) { return fakeConn(c), nil }
+func (c fakeConnector) Driver() driver.Driver                          { return fakeDriver{} }
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return nil, driver.ErrSkip }
+
+type fakeConn fakeConnector
+
+func (c fakeConn) Prepare(string) (driver.Stmt, error) { return fakeStmt(c), nil }
+func (c fakeConn) Close() error                        { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+type fakeStmt fakeConnector
+
+func (s fakeStmt) Close() error                               { return nil }
+func (s fakeStmt) NumInput() int                              { return -1 }
+func (s fakeStmt) Exec([]driver.Value) (driver.Result, error) { return nil, driver.ErrSkip }
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error)  { return &fakeRows{stmt: s}, nil }
+
+type fakeRows struct {
+	stmt fakeStmt
+	next int
+}
+
+func (r *fakeRows) Columns() []string { return r.stmt.columns }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.stmt.rows) {
+		return io.EOF
+	}
+	copy(dest, r.stmt.rows[r.next])
+	r.next++
+	return nil
+}
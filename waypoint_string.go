@@ -31,6 +31,34 @@ func (w *WaypointString) IsContainer() bool                     { return false }
 func (w *WaypointString) Children() []Waypoint                  { return nil }
 func (w *WaypointString) Voyager(parserArg ...*Parser) *Voyager { return NewVoyager(w, parserArg...) }
 
+// Unit returns the minimal date unit of waypoint's layout (e.g. Day for "2006-01-02").
+// It's UnitUndefined when the layout is not specified or the waypoint is non calendar.
+func (w *WaypointString) Unit() DateUnit {
+	if w.layout == "" {
+		return UnitUndefined
+	}
+	if layoutDetails := ParseLayout(w.layout); layoutDetails != nil {
+		return layoutDetails.MinimalUnit
+	}
+	return UnitUndefined
+}
+
+// Start returns the beginning of the time span the waypoint stands for (the same as Time()).
+func (w *WaypointString) Start() time.Time { return w.t }
+
+// End returns the end (exclusive) of the time span the waypoint stands for,
+// e.g. March 6th for "2024-03-05" parsed via "2006-01-02" layout. See SpanWaypoint.
+// Strings parsed without a layout or via a layout having clock parts are instants.
+func (w *WaypointString) End() time.Time {
+	if w.layout == "" || w.t.IsZero() {
+		return w.t
+	}
+	if unit := spanUnit(w.layout); unit.Defined() {
+		return unit.add(w.t, 1)
+	}
+	return w.t
+}
+
 func NewWaypointString(v string, layoutArg ...string) *WaypointString {
 	w := &WaypointString{timeInput: v}
 	if len(layoutArg) > 0 {
@@ -1,10 +1,8 @@
 package years
 
 import (
-	"fmt"
-	"log/slog"
+	"io/fs"
 	"os"
-	"path/filepath"
 	"strings"
 	"time"
 )
@@ -35,15 +33,81 @@ type TimeNamedWaypointFiles []*TimeNamedWaypointFile
 
 func (w *TimeNamedWaypointFile) Time() time.Time { return w.t }
 
+// Unit returns the minimal date unit of waypoint's layout (e.g. Day for "2006/Jan/2006-01-02.txt").
+// It's UnitUndefined for non calendar waypoints.
+func (w *TimeNamedWaypointFile) Unit() DateUnit { return w.unit }
+
+// Start returns the beginning of the time span the waypoint stands for (the same as Time()).
+func (w *TimeNamedWaypointFile) Start() time.Time { return w.t }
+
+// End returns the end (exclusive) of the time span the waypoint stands for,
+// e.g. April 1st for "2024/Mar" directory. See SpanWaypoint.
+func (w *TimeNamedWaypointFile) End() time.Time {
+	if unit := spanUnit(w.layout); unit.Defined() && !w.t.IsZero() {
+		return unit.add(w.t, 1)
+	}
+	return w.t
+}
+
+// NewTimeNamedWaypointFile creates a waypoint for the given path, taking the time from its name
+// (and names of its parents) via the given full layout, e.g. "2006/Jan/2006-01-02.txt".
+// Optional parent is the waypoint of the parent directory (its layout and time input are inherited).
+// Default file options are used, see NewTimeNamedWaypointFileWithOptions for configuring them.
 func NewTimeNamedWaypointFile(
 	path string, fullLayout string,
 	parentArg ...*TimeNamedWaypointFile,
 ) (*TimeNamedWaypointFile, error) {
-	stat, err := os.Stat(path)
+	var parent *TimeNamedWaypointFile
+	if len(parentArg) > 0 {
+		parent = parentArg[0]
+	}
+
+	config := newFileConfig()
+	w, err := newTimeNamedWaypointFile(path, fullLayout, parent, config)
+	if err != nil {
+		return nil, unwrapHalt(err)
+	}
+
+	return w, config.collectedErrors()
+}
+
+// NewTimeNamedWaypointFileWithOptions is NewTimeNamedWaypointFile configured via the given file options.
+// With FileErrorsCollect policy, the tree is returned along with all collected errors joined.
+func NewTimeNamedWaypointFileWithOptions(
+	path string, fullLayout string,
+	opts ...FileOption,
+) (*TimeNamedWaypointFile, error) {
+	config := newFileConfig(opts...)
+	w, err := newTimeNamedWaypointFile(path, fullLayout, nil, config)
+	if err != nil {
+		return nil, unwrapHalt(err)
+	}
+
+	return w, config.collectedErrors()
+}
+
+// NewTimeNamedWaypointFileFS creates a time named waypoint for the given path in the given file system
+// (e.g. embed.FS). See NewTimeNamedWaypointFileWithOptions.
+func NewTimeNamedWaypointFileFS(
+	fsys fs.FS, path string, fullLayout string, opts ...FileOption,
+) (*TimeNamedWaypointFile, error) {
+	return NewTimeNamedWaypointFileWithOptions(path, fullLayout, append([]FileOption{withFS(fsys)}, opts...)...)
+}
+
+func newTimeNamedWaypointFile(
+	path string, fullLayout string,
+	parent *TimeNamedWaypointFile, config *fileConfig,
+) (*TimeNamedWaypointFile, error) {
+	stat, err := config.fs.Stat(path)
 	if err != nil {
 		return nil, err
 	}
-	w0 := &WaypointFile{path: path, fileInfo: stat, t: stat.ModTime()}
+	w0 := &WaypointFile{path: path, fileInfo: stat, fsys: config.fs, t: stat.ModTime()}
+	if parent != nil {
+		w0.descend(parent.WaypointFile, config)
+	} else {
+		w0.descend(nil, config)
+	}
 	w := &TimeNamedWaypointFile{WaypointFile: w0}
 
 	fullLayoutParts := strings.Split(fullLayout, string(os.PathSeparator))
@@ -51,8 +115,7 @@ func NewTimeNamedWaypointFile(
 
 	w.timeInput = w.fileInfo.Name()
 
-	if len(parentArg) > 0 && parentArg[0] != nil {
-		parent := parentArg[0]
+	if parent != nil {
 		ownLayout := strings.TrimPrefix(fullLayout, parent.layout+"/")
 
 		if w.fileInfo.IsDir() {
@@ -71,6 +134,11 @@ func NewTimeNamedWaypointFile(
 
 	// Default parser is used. Use years.SetParserDefaults to configure parsing
 	w.t, err = NewParser().Parse(layout, w.timeInput)
+	if _, isArchive := stat.(archiveRootInfo); err != nil && isArchive {
+		// archives may stand for directories, e.g. "2024/03.tar.gz" for "2006/01" layout
+		w.timeInput = strings.TrimSuffix(w.timeInput, archiveExtension(stat.Name()))
+		w.t, err = NewParser().Parse(layout, w.timeInput)
+	}
 	if err != nil {
 		w.setNonCalendar()
 	} else {
@@ -78,35 +146,11 @@ func NewTimeNamedWaypointFile(
 		w.unit = layoutDetails.MinimalUnit
 	}
 
-	if w.fileInfo.IsDir() {
-		// Go deeper in the directory
-		innerPaths, err := filepath.Glob(filepath.Join(w.path, "*"))
-		if err != nil {
-			return nil, err
-		}
-
-		for _, innerPath := range innerPaths {
-			child, err := NewTimeNamedWaypointFile(innerPath, fullLayout, w)
-			if err != nil {
-				// TODO(nice-to-have): add configurable way to halt on child error, to log/omit errors, etc
-				slog.Info(fmt.Sprintf("child: NewTimeNamedWaypointFile(%s) failed: %s", innerPath, err))
-				continue
-			}
-
-			// By default, let's sort nodes in Past->Future order
-			var inserted bool
-			for i, existed := range w.waypoints {
-				if existed.Time().After(child.t) {
-					w.waypoints = append(w.waypoints[:i+1], w.waypoints[i:]...)
-					w.waypoints[i] = child
-					inserted = true
-					break
-				}
-			}
-			if !inserted {
-				w.waypoints = append(w.waypoints, child)
-			}
-		}
+	err = w.setChildren(config, func(innerPath string) (Waypoint, error) {
+		return newTimeNamedWaypointFile(innerPath, fullLayout, w, config)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return w, nil